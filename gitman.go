@@ -3,9 +3,11 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -72,9 +74,37 @@ func (gm *GitManager) runGitCommand(args ...string) (string, error) {
 	return strings.TrimSpace(string(output)), err
 }
 
+// Exécute une commande Git dans un autre répertoire (ex: un autre worktree)
+func (gm *GitManager) runGitCommandIn(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// Exécute une commande Git en lui passant input sur l'entrée standard
+func (gm *GitManager) runGitCommandWithInput(input string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = gm.currentPath
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// Exécute une commande Git avec un environnement supplémentaire (ex: GIT_INDEX_FILE) et une entrée standard
+func (gm *GitManager) runGitCommandWithEnv(env []string, input string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = gm.currentPath
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = strings.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// Fonctionne aussi dans un sous-répertoire ou un worktree, où .git est un fichier
 func (gm *GitManager) isGitRepo() bool {
-	_, err := os.Stat(filepath.Join(gm.currentPath, ".git"))
-	return err == nil
+	output, err := gm.runGitCommand("rev-parse", "--is-inside-work-tree")
+	return err == nil && output == "true"
 }
 
 // UI and Menu
@@ -118,6 +148,8 @@ func (gm *GitManager) showMenu() {
 	fmt.Printf("%s 9.%s  🔧 Outils et configuration\n", ColorGreen, ColorReset)
 	fmt.Printf("%s10.%s  📂 Changer de répertoire\n", ColorGreen, ColorReset)
 	fmt.Printf("%s11.%s  🚀 Initialiser un nouveau dépôt\n", ColorGreen, ColorReset)
+	fmt.Printf("%s12.%s  🔀 Workflow (feature/release/hotfix)\n", ColorGreen, ColorReset)
+	fmt.Printf("%s13.%s  🌲 Worktrees\n", ColorGreen, ColorReset)
 	fmt.Printf("%s 0.%s  🚪 Quitter\n", ColorRed, ColorReset)
 
 	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
@@ -127,7 +159,17 @@ func (gm *GitManager) showQuickStatus() {
 	branch := gm.getCurrentBranch()
 	status := gm.getGitStatus()
 
-	fmt.Printf("%s%s📍 Branche actuelle: %s%s%s\n", ColorBold, ColorGreen, ColorCyan, branch, ColorReset)
+	fmt.Printf("%s%s📍 Branche actuelle: %s%s%s", ColorBold, ColorGreen, ColorCyan, branch, ColorReset)
+	if upstream := gm.getUpstream(branch); upstream != "" {
+		fmt.Printf(" %s→ %s%s", ColorPurple, upstream, ColorReset)
+	} else {
+		fmt.Printf(" %s(aucun upstream)%s", ColorYellow, ColorReset)
+	}
+	fmt.Println()
+
+	if operation := gm.getOperationInProgress(); operation != "" {
+		fmt.Printf("%s⏸️  %s en cours → Commits (3) puis option 8 pour continuer/annuler%s\n", ColorYellow, operation, ColorReset)
+	}
 
 	if status != "" {
 		lines := strings.Split(strings.TrimSpace(status), "\n")
@@ -197,6 +239,44 @@ func (gm *GitManager) getGitStatus() string {
 	return output
 }
 
+// Lit une valeur de configuration Git (chaîne vide si absente)
+func (gm *GitManager) getGitConfig(key string) string {
+	output, err := gm.runGitCommand("config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return output
+}
+
+// Lit une liste séparée par des virgules depuis la configuration Git
+func (gm *GitManager) getGitConfigList(key string, defaults []string) []string {
+	value := gm.getGitConfig(key)
+	if value == "" {
+		return defaults
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	// Une valeur sans élément (ex: ",") équivaut à une clé absente
+	if len(items) == 0 {
+		return defaults
+	}
+	return items
+}
+
+// Retourne la branche upstream (ex: origin/main) d'une branche locale, ou "" si aucune
+func (gm *GitManager) getUpstream(branch string) string {
+	output, err := gm.runGitCommand("rev-parse", "--abbrev-ref", "--symbolic-full-name", branch+"@{u}")
+	if err != nil {
+		return ""
+	}
+	return output
+}
+
 // Menu Handlers
 // Version améliorée de handleDetailedStatus avec intelligence contextuelle
 func (gm *GitManager) handleDetailedStatus() {
@@ -235,7 +315,11 @@ func (gm *GitManager) showBasicRepoInfo() {
 	currentBranch := gm.getCurrentBranch()
 	lastCommit, _ := gm.runGitCommand("log", "-1", "--pretty=format:%h - %s (%an, %ar)")
 
-	fmt.Printf("%s🏠 DÉPÔT:%s %s\n", ColorBold, ColorReset, filepath.Base(gm.currentPath))
+	repoRoot, err := gm.runGitCommand("rev-parse", "--show-toplevel")
+	if err != nil {
+		repoRoot = gm.currentPath
+	}
+	fmt.Printf("%s🏠 DÉPÔT:%s %s\n", ColorBold, ColorReset, filepath.Base(repoRoot))
 	fmt.Printf("%s🌿 BRANCHE ACTUELLE:%s %s%s%s\n", ColorBold, ColorReset, ColorCyan, currentBranch, ColorReset)
 	fmt.Printf("%s📦 DERNIER COMMIT:%s %s\n", ColorBold, ColorReset, lastCommit)
 
@@ -387,9 +471,27 @@ func (gm *GitManager) showBranchInfo() {
 	}
 
 	fmt.Printf("%s📍 Branche actuelle:%s %s%s%s\n", ColorBlue, ColorReset, ColorCyan, currentBranch, ColorReset)
+	if upstream := gm.getUpstream(currentBranch); upstream != "" {
+		fmt.Printf("%s🔗 Upstream:%s %s%s%s\n", ColorBlue, ColorReset, ColorPurple, upstream, ColorReset)
+	} else {
+		fmt.Printf("%s⚠️  Aucun upstream configuré pour '%s'%s\n", ColorYellow, currentBranch, ColorReset)
+	}
 	fmt.Printf("%s🏠 Branches locales:%s %d\n", ColorBlue, ColorReset, localCount)
 	fmt.Printf("%s🌐 Branches remote:%s %d\n", ColorBlue, ColorReset, remoteCount)
 
+	// Branches locales sans upstream
+	trackingInfo, _ := gm.runGitCommand("for-each-ref", "--format=%(refname:short)|%(upstream:short)", "refs/heads/")
+	var noUpstream []string
+	for _, line := range strings.Split(trackingInfo, "\n") {
+		parts := strings.Split(line, "|")
+		if len(parts) == 2 && parts[0] != "" && parts[1] == "" {
+			noUpstream = append(noUpstream, parts[0])
+		}
+	}
+	if len(noUpstream) > 0 {
+		fmt.Printf("%s🔌 Branches sans upstream (%d):%s %s\n", ColorYellow, len(noUpstream), ColorReset, strings.Join(noUpstream, ", "))
+	}
+
 	// Vérifier si on est sur main/master
 	if currentBranch == "main" || currentBranch == "master" {
 		fmt.Printf("%s⚠️  Vous êtes sur la branche principale%s\n", ColorYellow, ColorReset)
@@ -439,6 +541,16 @@ func (gm *GitManager) showRemoteSync() {
 		fmt.Printf("%s🌐 Remote principal:%s %s\n", ColorBlue, ColorReset, originURL)
 	}
 
+	// Sans upstream, ahead/behind n'ont pas de sens
+	upstream := gm.getUpstream(gm.getCurrentBranch())
+	if upstream == "" {
+		fmt.Printf("%s⚠️  Aucun upstream configuré pour la branche actuelle%s\n", ColorYellow, ColorReset)
+		fmt.Printf("%s💡 Branches → 'Gérer l'upstream' ou push avec --set-upstream%s\n", ColorCyan, ColorReset)
+		fmt.Println()
+		return
+	}
+	fmt.Printf("%s🔗 Upstream:%s %s\n", ColorBlue, ColorReset, upstream)
+
 	// Vérifier l'état de synchronisation (commits en avance/retard)
 	// Effectuer un fetch silencieux pour s'assurer que les informations sont à jour
 	gm.runGitCommand("fetch", "origin")
@@ -527,6 +639,10 @@ func (gm *GitManager) showIntelligentSuggestions() {
 	}
 
 	// Suggestions basées sur la synchronisation
+	if currentBranch != "" && gm.getUpstream(currentBranch) == "" {
+		suggestions = append(suggestions, "🔌 Aucun upstream pour cette branche → Tapez 'R' puis '1' pour pusher avec --set-upstream")
+	}
+
 	if ahead != "" && ahead != "0" {
 		suggestions = append(suggestions, "📤 Vous avez des commits locaux → Tapez 'R' puis '1' pour pusher")
 	}
@@ -574,8 +690,8 @@ func (gm *GitManager) createBranchFromCommit() {
 	recentCommits, _ := gm.runGitCommand("log", "--oneline", "--graph", "-10")
 	fmt.Println(recentCommits)
 
-	fmt.Printf("\n%sNom de la nouvelle branche: %s", ColorYellow, ColorReset)
-	branchName := gm.getUserInput()
+	fmt.Println()
+	branchName := gm.promptBranchName("Nom de la nouvelle branche")
 
 	if branchName == "" {
 		fmt.Printf("%s❌ Nom de branche invalide!%s\n", ColorRed, ColorReset)
@@ -629,6 +745,8 @@ func (gm *GitManager) handleBranchManagement() {
 		fmt.Println("5. Renommer une branche")
 		fmt.Println("6. Merger une branche")
 		fmt.Println("7. Voir les branches remote")
+		fmt.Println("8. Gérer l'upstream (suivi)")
+		fmt.Println("9. Cherry-pick depuis une autre branche")
 		fmt.Println("0. Retour au menu principal")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
@@ -649,6 +767,10 @@ func (gm *GitManager) handleBranchManagement() {
 			gm.mergeBranch()
 		case "7":
 			gm.showRemoteBranches()
+		case "8":
+			gm.manageUpstream()
+		case "9":
+			gm.cherryPickBrowser()
 		case "0":
 			return
 		default:
@@ -676,6 +798,10 @@ func (gm *GitManager) handleCommitManagement() {
 		fmt.Println("4. Modifier le dernier commit (amend)")
 		fmt.Println("5. Reset / Revert")
 		fmt.Println("6. Chercher dans les commits")
+		fmt.Println("7. Rebase interactif")
+		fmt.Println("8. Continuer / passer / annuler l'opération en cours")
+		fmt.Println("9. Signature des commits et tags")
+		fmt.Println("10. Corriger un commit (fixup / squash + autosquash)")
 		fmt.Println("0. Retour au menu principal")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
@@ -694,6 +820,14 @@ func (gm *GitManager) handleCommitManagement() {
 			gm.handleResetRevert()
 		case "6":
 			gm.searchHistory()
+		case "7":
+			gm.interactiveRebase()
+		case "8":
+			gm.handleOperationInProgress()
+		case "9":
+			gm.handleSigning()
+		case "10":
+			gm.fixupCommit()
 		case "0":
 			return
 		default:
@@ -813,7 +947,8 @@ func (gm *GitManager) handleTagManagement() {
 		fmt.Printf("%s%s🏷️  GESTION DES TAGS%s\n", ColorBold, ColorGreen, ColorReset)
 		fmt.Println(strings.Repeat("═", 25))
 
-		tags, _ := gm.runGitCommand("tag", "-l")
+		// versionsort.suffix: les pré-releases (-rc.1) avant la version finale
+		tags, _ := gm.runGitCommand("-c", "versionsort.suffix=-", "tag", "-l", "--sort=-version:refname")
 		if tags != "" {
 			fmt.Printf("%s🏷️  Tags existants:%s\n", ColorBlue, ColorReset)
 			fmt.Println(tags)
@@ -824,7 +959,10 @@ func (gm *GitManager) handleTagManagement() {
 		fmt.Println("2. Créer un tag annoté")
 		fmt.Println("3. Supprimer un tag")
 		fmt.Println("4. Voir les détails d'un tag")
-		fmt.Println("5. Lister tous les tags")
+		fmt.Println("5. Parcourir les tags (versions, diff de release)")
+		fmt.Println("6. Générer un changelog")
+		fmt.Println("7. Créer une release (version sémantique)")
+		fmt.Println("8. Tags distants (push, suppression, synchronisation)")
 		fmt.Println("0. Retour au menu principal")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
@@ -841,6 +979,12 @@ func (gm *GitManager) handleTagManagement() {
 			gm.showTagDetails()
 		case "5":
 			gm.listTags()
+		case "6":
+			gm.handleChangelog()
+		case "7":
+			gm.handleRelease()
+		case "8":
+			gm.handleRemoteTags()
 		case "0":
 			return
 		default:
@@ -866,6 +1010,16 @@ func (gm *GitManager) handleStashManagement() {
 		if stashes != "" {
 			fmt.Printf("%s🗂️  Stashes existants:%s\n", ColorBlue, ColorReset)
 			fmt.Println(stashes)
+
+			maxAge, stale := gm.stashMaxAge(), 0
+			for _, entry := range gm.loadStashes() {
+				if entry.isStale(maxAge) {
+					stale++
+				}
+			}
+			if stale > 0 {
+				fmt.Printf("%s⏰ %d stash(es) de plus de %d jours, à appliquer ou supprimer (option 7)%s\n", ColorYellow, stale, maxAge, ColorReset)
+			}
 			fmt.Println()
 		}
 
@@ -875,6 +1029,9 @@ func (gm *GitManager) handleStashManagement() {
 		fmt.Println("4. Supprimer un stash")
 		fmt.Println("5. Supprimer tous les stashes")
 		fmt.Println("6. Créer une branche depuis un stash")
+		fmt.Println("7. Parcourir les stashes (fichiers, application partielle, renommage)")
+		fmt.Println("8. Exporter un stash vers un fichier patch")
+		fmt.Println("9. Importer un patch comme stash")
 		fmt.Println("0. Retour au menu principal")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
@@ -893,6 +1050,12 @@ func (gm *GitManager) handleStashManagement() {
 			gm.clearStashes()
 		case "6":
 			gm.stashToBranch()
+		case "7":
+			gm.browseStashes()
+		case "8":
+			gm.exportStash()
+		case "9":
+			gm.importStash()
 		case "0":
 			return
 		default:
@@ -974,6 +1137,8 @@ func (gm *GitManager) handleTools() {
 		fmt.Println("4. Hooks Git")
 		fmt.Println("5. Aliases Git")
 		fmt.Println("6. Sauvegarde/Archive")
+		fmt.Println("7. Bisect guidé (trouver le commit fautif)")
+		fmt.Println("8. Reflog et récupération de travail perdu")
 		fmt.Println("0. Retour au menu principal")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
@@ -992,6 +1157,10 @@ func (gm *GitManager) handleTools() {
 			gm.manageAliases()
 		case "6":
 			gm.archiveRepo()
+		case "7":
+			gm.handleBisect()
+		case "8":
+			gm.handleReflog()
 		case "0":
 			return
 		default:
@@ -1001,10 +1170,154 @@ func (gm *GitManager) handleTools() {
 	}
 }
 
+// Branch Naming
+// Vérifie un nom de branche avec git check-ref-format puis avec la règle gitman.branchPattern
+func (gm *GitManager) validateBranchName(name string) error {
+	if output, err := gm.runGitCommand("check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("nom refusé par git: %s", strings.TrimPrefix(output, "fatal: "))
+	}
+
+	pattern := gm.getGitConfig("gitman.branchPattern")
+	if pattern == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("règle gitman.branchPattern invalide: %v", err)
+	}
+	if !re.MatchString(name) {
+		return fmt.Errorf("'%s' ne respecte pas la règle de nommage %s", name, pattern)
+	}
+	return nil
+}
+
+// Transforme une description libre en slug (minuscules, sans accents, séparé par des tirets)
+func slugify(text string) string {
+	replacer := strings.NewReplacer(
+		"à", "a", "â", "a", "ä", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e",
+		"î", "i", "ï", "i", "ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u", "ÿ", "y",
+		"œ", "oe", "æ", "ae",
+	)
+	text = replacer.Replace(strings.ToLower(text))
+
+	var slug strings.Builder
+	lastDash := true
+	for _, r := range text {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			slug.WriteRune(r)
+			lastDash = false
+		} else if !lastDash {
+			slug.WriteRune('-')
+			lastDash = true
+		}
+	}
+
+	result := strings.Trim(slug.String(), "-")
+	if len(result) > 50 {
+		result = strings.Trim(result[:50], "-")
+	}
+	return result
+}
+
+// Construit un nom de branche à partir du modèle gitman.branchTemplate (type, ticket, description)
+func (gm *GitManager) buildBranchNameFromTemplate() string {
+	types := gm.getGitConfigList("gitman.branchTypes", []string{"feature", "fix", "chore"})
+
+	fmt.Printf("%sTypes disponibles:%s\n", ColorBlue, ColorReset)
+	for i, t := range types {
+		fmt.Printf("  %d. %s\n", i+1, t)
+	}
+	fmt.Printf("%sType (numéro ou nom, défaut 1): %s", ColorYellow, ColorReset)
+	typeChoice := gm.getUserInput()
+
+	branchType := types[0]
+	if typeChoice != "" {
+		if i, err := strconv.Atoi(typeChoice); err == nil && i >= 1 && i <= len(types) {
+			branchType = types[i-1]
+		} else {
+			branchType = typeChoice
+		}
+	}
+
+	fmt.Printf("%sID du ticket (ex: PROJ-123, optionnel): %s", ColorYellow, ColorReset)
+	ticket := strings.ToUpper(gm.getUserInput())
+
+	fmt.Printf("%sDescription courte: %s", ColorYellow, ColorReset)
+	slug := slugify(gm.getUserInput())
+	if slug == "" {
+		return ""
+	}
+
+	template := gm.getGitConfig("gitman.branchTemplate")
+	if template == "" {
+		template = "{type}/{ticket}-{slug}"
+	}
+
+	name := strings.NewReplacer("{type}", branchType, "{ticket}", ticket, "{slug}", slug).Replace(template)
+	// Nettoyer les séparateurs orphelins quand le ticket est absent
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	name = strings.ReplaceAll(name, "/-", "/")
+	name = strings.ReplaceAll(name, "-/", "/")
+	return strings.Trim(name, "-/")
+}
+
+// Demande un nom de branche (saisie libre ou modèle guidé) et le valide; retourne "" si invalide
+func (gm *GitManager) promptBranchName(prompt string) string {
+	fmt.Printf("%s%s (Entrée pour le modèle guidé): %s", ColorYellow, prompt, ColorReset)
+	name := gm.getUserInput()
+
+	if name == "" {
+		name = gm.buildBranchNameFromTemplate()
+		if name == "" {
+			return ""
+		}
+		fmt.Printf("%s🌿 Nom proposé: %s%s%s\n", ColorBlue, ColorCyan, name, ColorReset)
+	}
+
+	if err := gm.validateBranchName(name); err != nil {
+		fmt.Printf("%s❌ %v%s\n", ColorRed, err, ColorReset)
+		return ""
+	}
+	return name
+}
+
+// Protected Branches
+// Une branche est protégée si elle correspond à un motif de gitman.protectedBranches (défaut: main, master)
+func (gm *GitManager) isProtectedBranch(branch string) bool {
+	for _, pattern := range gm.getGitConfigList("gitman.protectedBranches", []string{"main", "master"}) {
+		if matched, err := filepath.Match(pattern, branch); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Bloque l'action ou exige de retaper le nom de la branche selon gitman.protectedMode (block/confirm)
+func (gm *GitManager) confirmProtectedAction(branch, action string) bool {
+	if !gm.isProtectedBranch(branch) {
+		return true
+	}
+
+	if gm.getGitConfig("gitman.protectedMode") == "block" {
+		fmt.Printf("%s🛡️  '%s' est une branche protégée: %s impossible.%s\n", ColorRed, branch, action, ColorReset)
+		return false
+	}
+
+	fmt.Printf("%s🛡️  '%s' est une branche protégée (%s).%s\n", ColorRed, branch, action, ColorReset)
+	fmt.Printf("%sTapez le nom de la branche pour confirmer: %s", ColorRed, ColorReset)
+	if gm.getUserInput() != branch {
+		fmt.Printf("%s❌ Confirmation incorrecte, action annulée.%s\n", ColorRed, ColorReset)
+		return false
+	}
+	return true
+}
+
 // Branch Management
 func (gm *GitManager) createBranch() {
-	fmt.Printf("%sNom de la nouvelle branche: %s", ColorYellow, ColorReset)
-	branchName := gm.getUserInput()
+	branchName := gm.promptBranchName("Nom de la nouvelle branche")
 
 	if branchName == "" {
 		fmt.Printf("%s❌ Nom de branche invalide!%s\n", ColorRed, ColorReset)
@@ -1058,16 +1371,23 @@ func (gm *GitManager) deleteBranch() {
 		return
 	}
 
+	if !gm.confirmProtectedAction(branchName, "suppression") {
+		gm.pause()
+		return
+	}
+
 	fmt.Printf("%s⚠️  Êtes-vous sûr de vouloir supprimer '%s'? (y/N): %s", ColorRed, branchName, ColorReset)
 	confirm := gm.getUserInput()
 
 	if strings.ToLower(confirm) == "y" {
+		tip, _ := gm.runGitCommand("rev-parse", "--short", branchName)
 		output, err := gm.runGitCommand("branch", "-d", branchName)
 		if err != nil {
 			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
 			fmt.Printf("%s💡 Utilisez 'git branch -D %s' pour forcer la suppression%s\n", ColorYellow, branchName, ColorReset)
 		} else {
 			fmt.Printf("%s✅ Branche '%s' supprimée!%s\n", ColorGreen, branchName, ColorReset)
+			fmt.Printf("%s💡 Pour la récupérer: Outils > Reflog (dernier commit %s)%s\n", ColorCyan, tip, ColorReset)
 		}
 	}
 	gm.pause()
@@ -1077,8 +1397,7 @@ func (gm *GitManager) renameBranch() {
 	currentBranch := gm.getCurrentBranch()
 	fmt.Printf("%sBranche actuelle: %s%s%s\n", ColorBlue, ColorCyan, currentBranch, ColorReset)
 
-	fmt.Printf("%sNouveau nom: %s", ColorYellow, ColorReset)
-	newName := gm.getUserInput()
+	newName := gm.promptBranchName("Nouveau nom")
 
 	if newName == "" {
 		fmt.Printf("%s❌ Nom invalide!%s\n", ColorRed, ColorReset)
@@ -1112,1561 +1431,6284 @@ func (gm *GitManager) mergeBranch() {
 		return
 	}
 
-	output, err := gm.runGitCommand("merge", branchName)
+	incoming, err := gm.runGitCommand("log", "--oneline", "--no-decorate", "HEAD.."+branchName)
 	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Branche '%s' mergée dans '%s'!%s\n", ColorGreen, branchName, currentBranch, ColorReset)
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, incoming, ColorReset)
+		gm.pause()
+		return
 	}
-	gm.pause()
-}
-
-func (gm *GitManager) showRemoteBranches() {
-	output, err := gm.runGitCommand("branch", "-r")
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s🌐 Branches remote:%s\n", ColorCyan, ColorReset)
-		fmt.Println(output)
+	if incoming == "" {
+		fmt.Printf("%s✅ '%s' est déjà intégrée dans '%s'.%s\n", ColorGreen, branchName, currentBranch, ColorReset)
+		gm.pause()
+		return
 	}
-	gm.pause()
-}
+	fmt.Printf("\n%s📥 Commits à intégrer (%d):%s\n", ColorBlue, len(strings.Split(incoming, "\n")), ColorReset)
+	fmt.Println(incoming)
 
-// Commit Management
-func (gm *GitManager) makeCommit() {
-	staged, _ := gm.runGitCommand("diff", "--cached", "--name-only")
-	if staged == "" {
-		fmt.Printf("%sAucun fichier en stage. Voulez-vous ajouter des fichiers? (y/N): %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
-		if strings.ToLower(choice) == "y" {
-			gm.addFiles()
-			staged, _ = gm.runGitCommand("diff", "--cached", "--name-only")
-			if staged == "" {
-				fmt.Printf("%s❌ Aucun fichier en stage après ajout. Annulation du commit.%s\n", ColorRed, ColorReset)
-				gm.pause()
-				return
-			}
-		} else {
+	// Refuser un arbre modifié, sauf autostash explicite
+	autostash := false
+	if status, _ := gm.runGitCommand("status", "--porcelain", "--untracked-files=no"); status != "" {
+		fmt.Printf("\n%s⚠️  L'arbre de travail contient des modifications non commitées.%s\n", ColorYellow, ColorReset)
+		fmt.Printf("%sLes mettre de côté pendant le merge (--autostash)? (y/N): %s", ColorYellow, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
+			fmt.Printf("%s❌ Merge annulé: commitez ou stashez vos changements d'abord.%s\n", ColorRed, ColorReset)
 			gm.pause()
 			return
 		}
+		autostash = true
+	}
+
+	// Merge d'essai sans toucher à l'arbre de travail
+	fmt.Printf("\n%s🔎 Merge d'essai (merge-tree)...%s\n", ColorBlue, ColorReset)
+	trial, err := gm.runGitCommand("merge-tree", "--write-tree", "--name-only", "--no-messages", "HEAD", branchName)
+	trialLines := strings.Split(trial, "\n")
+	isTree := len(trialLines[0]) >= 40 && !strings.ContainsAny(trialLines[0], " :")
+	switch {
+	case err == nil:
+		fmt.Printf("%s✅ Aucun conflit prévu.%s\n", ColorGreen, ColorReset)
+	case isTree:
+		fmt.Printf("%s⚔️  Conflits prévus dans %d fichier(s):%s\n", ColorRed, len(trialLines)-1, ColorReset)
+		for _, file := range trialLines[1:] {
+			fmt.Printf("   %s✗%s %s\n", ColorRed, ColorReset, file)
+		}
+	default:
+		fmt.Printf("%s⚠️  Aperçu indisponible (git 2.38+ requis): %s%s\n", ColorYellow, trialLines[0], ColorReset)
 	}
 
-	fmt.Printf("%sMessage de commit: %s", ColorYellow, ColorReset)
-	message := gm.getUserInput()
+	fmt.Println("\nType de merge:")
+	fmt.Println("1. Par défaut (fast-forward si possible)")
+	fmt.Println("2. Fast-forward uniquement (--ff-only)")
+	fmt.Println("3. Toujours créer un commit de merge (--no-ff)")
+	fmt.Println("4. Squash (--squash, un seul commit à créer)")
+	fmt.Println("0. Annuler")
+	fmt.Printf("\n%sChoisissez une option (défaut 1): %s", ColorYellow, ColorReset)
 
-	if message == "" {
-		fmt.Printf("%s❌ Message de commit requis!%s\n", ColorRed, ColorReset)
+	args := []string{"merge"}
+	mode := gm.getUserInput()
+	switch mode {
+	case "", "1":
+		mode = "1"
+	case "2":
+		args = append(args, "--ff-only")
+	case "3":
+		args = append(args, "--no-ff")
+	case "4":
+		args = append(args, "--squash")
+	case "0":
+		return
+	default:
+		fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
 		gm.pause()
 		return
 	}
 
-	output, err := gm.runGitCommand("commit", "-m", message)
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Commit créé!%s\n", ColorGreen, ColorReset)
-		fmt.Println(output)
+	if mode != "2" {
+		fmt.Printf("%sStratégie en cas de conflit: 1. aucune  2. -X ours  3. -X theirs (défaut 1): %s", ColorYellow, ColorReset)
+		switch gm.getUserInput() {
+		case "2":
+			args = append(args, "-X", "ours")
+		case "3":
+			args = append(args, "-X", "theirs")
+		}
 	}
-	gm.pause()
-}
 
-func (gm *GitManager) showCommitDetails() {
-	fmt.Printf("%sHash du commit (vide pour le dernier): %s", ColorYellow, ColorReset)
-	commitHash := gm.getUserInput()
+	message := ""
+	if mode == "1" || mode == "3" || mode == "4" {
+		fmt.Printf("%sMessage du commit (vide pour le message par défaut): %s", ColorYellow, ColorReset)
+		message = gm.getUserInput()
+		if message != "" && mode != "4" {
+			args = append(args, "-m", message)
+		}
+	}
 
-	var output string
-	var err error
+	if autostash {
+		args = append(args, "--autostash")
+	}
+	if mode != "4" {
+		args = append(args, "--no-edit")
+	}
+	args = append(args, branchName)
 
-	// Ajout de l'option --color=always pour forcer la coloration
-	if commitHash == "" {
-		output, err = gm.runGitCommand("show", "--color=always", "HEAD")
-	} else {
-		output, err = gm.runGitCommand("show", "--color=always", commitHash)
+	if mode == "4" && message == "" {
+		message = fmt.Sprintf("Squash de la branche '%s'", branchName)
 	}
 
+	output, err := gm.runGitCommand(args...)
 	if err != nil {
 		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		if conflicts, _ := gm.runGitCommand("diff", "--name-only", "--diff-filter=U"); mode == "4" && conflicts != "" {
+			// Le commit de squash reprendra ce message une fois les conflits résolus
+			os.WriteFile(gm.gitPath("SQUASH_MSG"), []byte(message+"\n"), 0644)
+			gm.pause()
+			gm.handleOperationInProgress()
+			return
+		}
+		if gm.getOperationInProgress() == "merge" {
+			gm.pause()
+			gm.handleOperationInProgress()
+			return
+		}
+	} else if mode == "4" {
+		result, err := gm.runGitCommand("commit", "-m", message)
+		if err != nil {
+			fmt.Printf("%s❌ Erreur lors du commit: %s%s\n", ColorRed, result, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Branche '%s' squashée dans '%s'!%s\n", ColorGreen, branchName, currentBranch, ColorReset)
+		}
 	} else {
-		fmt.Println(output)
+		fmt.Printf("%s✅ Branche '%s' mergée dans '%s'!%s\n", ColorGreen, branchName, currentBranch, ColorReset)
 	}
 	gm.pause()
 }
 
-func (gm *GitManager) amendCommit() {
-	fmt.Printf("%sVoulez-vous modifier le message du dernier commit? (y/N): %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
-	var output string
-	var err error
+func (gm *GitManager) showRemoteBranches() {
+	output, err := gm.runGitCommand("branch", "-r")
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
 
-	if strings.ToLower(choice) == "y" {
-		fmt.Printf("%sNouveau message de commit: %s", ColorYellow, ColorReset)
-		newMessage := gm.getUserInput()
-		if newMessage == "" {
-			fmt.Printf("%s❌ Message de commit requis!%s\n", ColorRed, ColorReset)
-			gm.pause()
-			return
-		}
-		output, err = gm.runGitCommand("commit", "--amend", "-m", newMessage)
-	} else {
-		output, err = gm.runGitCommand("commit", "--amend", "--no-edit")
+	fmt.Printf("%s🌐 Branches remote:%s\n", ColorCyan, ColorReset)
+	fmt.Println(output)
+	if output == "" {
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("\n%sBranche remote à récupérer en local (ex: origin/feature, vide pour ignorer): %s", ColorYellow, ColorReset)
+	remoteBranch := gm.getUserInput()
+	if remoteBranch == "" {
+		return
 	}
 
+	slash := strings.Index(remoteBranch, "/")
+	if slash <= 0 || slash == len(remoteBranch)-1 {
+		fmt.Printf("%s❌ Format attendu: remote/branche%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	defaultName := remoteBranch[slash+1:]
+	fmt.Printf("%sNom de la branche locale (défaut '%s'): %s", ColorYellow, defaultName, ColorReset)
+	localName := gm.getUserInput()
+	if localName == "" {
+		localName = defaultName
+	}
+
+	result, err := gm.runGitCommand("checkout", "-b", localName, "--track", remoteBranch)
 	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, result, ColorReset)
 	} else {
-		fmt.Printf("%s✅ Dernier commit modifié!%s\n", ColorGreen, ColorReset)
+		fmt.Printf("%s✅ Branche locale '%s' créée et suit '%s'!%s\n", ColorGreen, localName, remoteBranch, ColorReset)
 	}
 	gm.pause()
 }
 
-func (gm *GitManager) handleResetRevert() {
-	for {
-		gm.clearScreen()
-		fmt.Printf("%s%s🔄 RESET / REVERT%s\n", ColorBold, ColorRed, ColorReset)
-		fmt.Println(strings.Repeat("═", 30))
-		fmt.Println("1. Reset (déplacer HEAD)")
-		fmt.Println("2. Revert (créer un commit d'annulation)")
-		fmt.Println("0. Retour")
-
-		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
+// Analyse une sélection du type "1 3 5-7" ou "all"; retourne des index (base 0) triés et uniques
+func parseSelection(input string, max int) []int {
+	selected := make(map[int]bool)
+	if strings.ToLower(strings.TrimSpace(input)) == "all" {
+		for i := 0; i < max; i++ {
+			selected[i] = true
+		}
+	}
 
-		switch choice {
-		case "1":
-			gm.handleReset()
-		case "2":
-			gm.handleRevert()
-		case "0":
-			return
-		default:
-			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
-			gm.pause()
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if bounds := strings.SplitN(field, "-", 2); len(bounds) == 2 {
+			start, err1 := strconv.Atoi(bounds[0])
+			end, err2 := strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			for n := start; n <= end; n++ {
+				if n >= 1 && n <= max {
+					selected[n-1] = true
+				}
+			}
+		} else if n, err := strconv.Atoi(field); err == nil && n >= 1 && n <= max {
+			selected[n-1] = true
 		}
 	}
+
+	var indexes []int
+	for i := range selected {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
 }
 
-func (gm *GitManager) handleReset() {
-	fmt.Printf("%sCommit cible (ex: HEAD~1, hash): %s", ColorYellow, ColorReset)
-	target := gm.getUserInput()
-	if target == "" {
-		fmt.Printf("%s❌ Cible requise!%s\n", ColorRed, ColorReset)
-		gm.pause()
+// Navigateur de cherry-pick: commits d'une autre branche absents de HEAD
+func (gm *GitManager) cherryPickBrowser() {
+	if operation := gm.getOperationInProgress(); operation != "" {
+		fmt.Printf("%s⚠️  Un %s est déjà en cours.%s\n", ColorYellow, operation, ColorReset)
+		gm.handleOperationInProgress()
 		return
 	}
 
-	fmt.Println("Types de reset:")
-	fmt.Println("1. --soft (ne touche pas à l'index ni à l'arbre de travail)")
-	fmt.Println("2. --mixed (défaut, reset l'index mais pas l'arbre de travail)")
-	fmt.Println("3. --hard (ATTENTION: perd les modifications locales)")
-
-	fmt.Printf("\n%sChoisissez un type de reset (défaut 2): %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
+	branches, _ := gm.runGitCommand("branch", "-a", "--format=%(refname:short)")
+	fmt.Printf("%sBranches disponibles:%s\n", ColorBlue, ColorReset)
+	fmt.Println(branches)
 
-	var resetType string
-	switch choice {
-	case "1":
-		resetType = "--soft"
-	case "3":
-		fmt.Printf("%s⚠️  Êtes-vous sûr de vouloir faire un reset --hard? (y/N): %s", ColorRed, ColorReset)
-		confirm := gm.getUserInput()
-		if strings.ToLower(confirm) != "y" {
-			gm.pause()
-			return
-		}
-		resetType = "--hard"
-	default:
-		resetType = "--mixed"
+	fmt.Printf("\n%sBranche source: %s", ColorYellow, ColorReset)
+	source := gm.getUserInput()
+	if source == "" {
+		fmt.Printf("%s❌ Nom de branche invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
 	}
 
-	output, err := gm.runGitCommand("reset", resetType, target)
+	output, err := gm.runGitCommand("log", "--cherry-pick", "--right-only", "--no-merges", "--reverse",
+		"--format=%h|%s|%an|%ar", "HEAD..."+source)
 	if err != nil {
 		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Reset effectué!%s\n", ColorGreen, ColorReset)
-		fmt.Println(output)
+		gm.pause()
+		return
 	}
-	gm.pause()
-}
-
-func (gm *GitManager) handleRevert() {
-	fmt.Printf("%sCommit à annuler (revert): %s", ColorYellow, ColorReset)
-	target := gm.getUserInput()
-	if target == "" {
-		fmt.Printf("%s❌ Cible requise!%s\n", ColorRed, ColorReset)
+	if output == "" {
+		fmt.Printf("%s✅ Tous les commits de '%s' sont déjà présents sur HEAD.%s\n", ColorGreen, source, ColorReset)
 		gm.pause()
 		return
 	}
 
-	output, err := gm.runGitCommand("revert", target)
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Revert effectué!%s\n", ColorGreen, ColorReset)
-		fmt.Println(output)
+	var hashes []string
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) == 4 {
+			hashes = append(hashes, parts[0])
+			lines = append(lines, fmt.Sprintf("%s%s%s %s %s(%s, %s)%s", ColorYellow, parts[0], ColorReset, parts[1], ColorCyan, parts[2], parts[3], ColorReset))
+		}
 	}
-	gm.pause()
-}
 
-// Remote Management
-func (gm *GitManager) addRemote() {
-	fmt.Printf("%sNom du remote (ex: origin): %s", ColorYellow, ColorReset)
-	name := gm.getUserInput()
-	fmt.Printf("%sURL du remote: %s", ColorYellow, ColorReset)
-	url := gm.getUserInput()
+	withProvenance := false
+	noCommit := false
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🍒 CHERRY-PICK depuis %s → %s%s\n", ColorBold, ColorPurple, source, gm.getCurrentBranch(), ColorReset)
+		fmt.Println(strings.Repeat("═", 50))
+		fmt.Printf("%sCommits absents de HEAD (du plus ancien au plus récent):%s\n", ColorBlue, ColorReset)
+		for i, line := range lines {
+			fmt.Printf("%3d. %s\n", i+1, line)
+		}
 
-	if name == "" || url == "" {
-		fmt.Printf("%s❌ Nom et URL sont requis!%s\n", ColorRed, ColorReset)
+		fmt.Printf("\n%sOptions:%s -x (provenance)=%v  --no-commit=%v\n", ColorBlue, ColorReset, withProvenance, noCommit)
+		fmt.Println("Entrez les numéros à appliquer (ex: 1 3 5-7, all), 'x' ou 'n' pour basculer une option, vide pour annuler")
+
+		fmt.Printf("\n%sSélection: %s", ColorYellow, ColorReset)
+		input := gm.getUserInput()
+		switch strings.ToLower(input) {
+		case "":
+			return
+		case "x":
+			withProvenance = !withProvenance
+			continue
+		case "n":
+			noCommit = !noCommit
+			continue
+		}
+
+		indexes := parseSelection(input, len(hashes))
+		if len(indexes) == 0 {
+			fmt.Printf("%s❌ Sélection invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			continue
+		}
+
+		args := []string{"cherry-pick"}
+		if withProvenance {
+			args = append(args, "-x")
+		}
+		if noCommit {
+			args = append(args, "--no-commit")
+		}
+		for _, i := range indexes {
+			args = append(args, hashes[i])
+		}
+
+		fmt.Printf("%s🍒 Application de %d commit(s)...%s\n", ColorYellow, len(indexes), ColorReset)
+		result, err := gm.runGitCommand(args...)
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, result, ColorReset)
+			if gm.getOperationInProgress() == "cherry-pick" {
+				gm.pause()
+				gm.handleOperationInProgress()
+				return
+			}
+		} else if noCommit {
+			fmt.Printf("%s✅ Changements appliqués dans l'index (sans commit).%s\n", ColorGreen, ColorReset)
+		} else {
+			fmt.Printf("%s✅ %d commit(s) appliqué(s)!%s\n", ColorGreen, len(indexes), ColorReset)
+			applied, _ := gm.runGitCommand("log", "--oneline", fmt.Sprintf("-%d", len(indexes)))
+			fmt.Println(applied)
+		}
 		gm.pause()
 		return
 	}
+}
 
-	output, err := gm.runGitCommand("remote", "add", name, url)
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Remote '%s' ajouté!%s\n", ColorGreen, name, ColorReset)
+// Gestion de l'upstream (branche de suivi) des branches locales
+func (gm *GitManager) manageUpstream() {
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🔗 GESTION DE L'UPSTREAM%s\n", ColorBold, ColorPurple, ColorReset)
+		fmt.Println(strings.Repeat("═", 30))
+
+		currentBranch := gm.getCurrentBranch()
+		trackingInfo, _ := gm.runGitCommand("for-each-ref",
+			"--format=%(refname:short)|%(upstream:short)|%(upstream:track)", "refs/heads/")
+
+		fmt.Printf("%sBranches locales et leur upstream:%s\n", ColorBlue, ColorReset)
+		for _, line := range strings.Split(trackingInfo, "\n") {
+			parts := strings.Split(line, "|")
+			if len(parts) < 3 || parts[0] == "" {
+				continue
+			}
+
+			marker := " "
+			if parts[0] == currentBranch {
+				marker = "*"
+			}
+
+			if parts[1] == "" {
+				fmt.Printf("  %s %-25s %s⚠️  aucun upstream%s\n", marker, parts[0], ColorYellow, ColorReset)
+			} else {
+				fmt.Printf("  %s %-25s %s→ %s%s %s\n", marker, parts[0], ColorPurple, parts[1], ColorReset, parts[2])
+			}
+		}
+
+		fmt.Println("\n1. Définir / changer l'upstream d'une branche")
+		fmt.Println("2. Supprimer l'upstream d'une branche")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			fmt.Printf("%sBranche locale (défaut '%s'): %s", ColorYellow, currentBranch, ColorReset)
+			branch := gm.getUserInput()
+			if branch == "" {
+				branch = currentBranch
+			}
+
+			remoteBranches, _ := gm.runGitCommand("branch", "-r")
+			if remoteBranches != "" {
+				fmt.Printf("%s🌐 Branches remote:%s\n", ColorCyan, ColorReset)
+				fmt.Println(remoteBranches)
+			}
+
+			fmt.Printf("%sUpstream (défaut 'origin/%s'): %s", ColorYellow, branch, ColorReset)
+			upstream := gm.getUserInput()
+			if upstream == "" {
+				upstream = "origin/" + branch
+			}
+
+			output, err := gm.runGitCommand("branch", "--set-upstream-to="+upstream, branch)
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				fmt.Printf("%s💡 Si la branche n'existe pas encore sur le remote, utilisez push avec --set-upstream%s\n", ColorYellow, ColorReset)
+			} else {
+				fmt.Printf("%s✅ '%s' suit maintenant '%s'!%s\n", ColorGreen, branch, upstream, ColorReset)
+			}
+			gm.pause()
+		case "2":
+			fmt.Printf("%sBranche locale (défaut '%s'): %s", ColorYellow, currentBranch, ColorReset)
+			branch := gm.getUserInput()
+			if branch == "" {
+				branch = currentBranch
+			}
+
+			output, err := gm.runGitCommand("branch", "--unset-upstream", branch)
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				fmt.Printf("%s✅ Upstream de '%s' supprimé!%s\n", ColorGreen, branch, ColorReset)
+			}
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
 	}
-	gm.pause()
 }
 
-func (gm *GitManager) removeRemote() {
-	fmt.Printf("%sNom du remote à supprimer: %s", ColorYellow, ColorReset)
-	name := gm.getUserInput()
-	if name == "" {
-		gm.pause()
-		return
+// Commit Management
+func (gm *GitManager) makeCommit() {
+	staged, _ := gm.runGitCommand("diff", "--cached", "--name-only")
+	if staged == "" {
+		fmt.Printf("%sAucun fichier en stage. Voulez-vous ajouter des fichiers? (y/N): %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+		if strings.ToLower(choice) == "y" {
+			gm.addFiles()
+			staged, _ = gm.runGitCommand("diff", "--cached", "--name-only")
+			if staged == "" {
+				fmt.Printf("%s❌ Aucun fichier en stage après ajout. Annulation du commit.%s\n", ColorRed, ColorReset)
+				gm.pause()
+				return
+			}
+		} else {
+			gm.pause()
+			return
+		}
 	}
 
-	output, err := gm.runGitCommand("remote", "remove", name)
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Remote '%s' supprimé!%s\n", ColorGreen, name, ColorReset)
+	if !gm.checkStagedChanges() {
+		gm.pause()
+		return
 	}
-	gm.pause()
-}
 
-func (gm *GitManager) renameRemote() {
-	fmt.Printf("%sAncien nom du remote: %s", ColorYellow, ColorReset)
-	oldName := gm.getUserInput()
-	fmt.Printf("%sNouveau nom du remote: %s", ColorYellow, ColorReset)
-	newName := gm.getUserInput()
+	message := gm.promptCommitMessage()
 
-	if oldName == "" || newName == "" {
-		fmt.Printf("%s❌ Les deux noms sont requis!%s\n", ColorRed, ColorReset)
+	if message == "" {
+		fmt.Printf("%s❌ Message de commit requis!%s\n", ColorRed, ColorReset)
 		gm.pause()
 		return
 	}
 
-	output, err := gm.runGitCommand("remote", "rename", oldName, newName)
+	output, err := gm.runGitCommand("commit", "-m", message)
 	if err != nil {
 		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
 	} else {
-		fmt.Printf("%s✅ Remote renommé de '%s' à '%s'!%s\n", ColorGreen, oldName, newName, ColorReset)
+		fmt.Printf("%s✅ Commit créé!%s\n", ColorGreen, ColorReset)
+		fmt.Println(output)
 	}
 	gm.pause()
 }
 
-func (gm *GitManager) fetchFromRemote() {
-	fmt.Printf("%sNom du remote (laisser vide pour 'all'): %s", ColorYellow, ColorReset)
-	remote := gm.getUserInput()
+func (gm *GitManager) showCommitDetails() {
+	fmt.Printf("%sHash du commit (vide pour le dernier): %s", ColorYellow, ColorReset)
+	commitHash := gm.getUserInput()
 
 	var output string
 	var err error
-	if remote == "" {
-		output, err = gm.runGitCommand("fetch", "--all", "--prune")
-	} else {
-		output, err = gm.runGitCommand("fetch", remote, "--prune")
+
+	// Ajout de l'option --color=always pour forcer la coloration
+	if commitHash == "" {
+		commitHash = "HEAD"
 	}
+	output, err = gm.runGitCommand("show", "--color=always", commitHash)
 
 	if err != nil {
 		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
 	} else {
-		fmt.Printf("%s✅ Fetch terminé!%s\n", ColorGreen, ColorReset)
+		signature, _ := gm.runGitCommand("log", "-1", "--format=%G?%x1f%GS%x1f%GK", commitHash)
+		fields := strings.Split(signature, "\x1f")
+		fmt.Printf("%sSignature:%s %s %s", ColorCyan, ColorReset, signatureBadge(fields[0]), signatureLabel(fields[0]))
+		if len(fields) == 3 && fields[1] != "" {
+			fmt.Printf(" par %s", fields[1])
+		}
+		if len(fields) == 3 && fields[2] != "" {
+			fmt.Printf(" (clé %s)", fields[2])
+		}
+		fmt.Println()
 		fmt.Println(output)
 	}
 	gm.pause()
 }
 
-func (gm *GitManager) pullFromRemote() {
-	fmt.Printf("%sRemote (défaut 'origin'): %s", ColorYellow, ColorReset)
-	remote := gm.getUserInput()
-	if remote == "" {
-		remote = "origin"
+func (gm *GitManager) amendCommit() {
+	if !gm.confirmProtectedAction(gm.getCurrentBranch(), "amend") {
+		gm.pause()
+		return
 	}
 
-	currentBranch := gm.getCurrentBranch()
-	fmt.Printf("%sBranche (défaut '%s'): %s", ColorYellow, currentBranch, ColorReset)
-	branch := gm.getUserInput()
-	if branch == "" {
-		branch = currentBranch
+	fmt.Printf("%sVoulez-vous modifier le message du dernier commit? (y/N): %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+	var output string
+	var err error
+
+	if strings.ToLower(choice) == "y" {
+		current, _ := gm.runGitCommand("log", "-1", "--format=%B")
+		fmt.Printf("%sMessage actuel:%s\n%s\n", ColorCyan, ColorReset, current)
+		newMessage, _ := gm.promptMessage("Nouveau message de commit", current, gm.commitMessageComments())
+		if newMessage == "" {
+			fmt.Printf("%s❌ Message de commit requis!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+		output, err = gm.runGitCommand("commit", "--amend", "-m", newMessage)
+	} else {
+		output, err = gm.runGitCommand("commit", "--amend", "--no-edit")
 	}
 
-	output, err := gm.runGitCommand("pull", remote, branch)
 	if err != nil {
 		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
 	} else {
-		fmt.Printf("%s✅ Pull terminé!%s\n", ColorGreen, ColorReset)
-		fmt.Println(output)
+		fmt.Printf("%s✅ Dernier commit modifié!%s\n", ColorGreen, ColorReset)
 	}
 	gm.pause()
 }
 
-func (gm *GitManager) pushToRemote() {
-	fmt.Printf("%sRemote (défaut 'origin'): %s", ColorYellow, ColorReset)
-	remote := gm.getUserInput()
-	if remote == "" {
-		remote = "origin"
-	}
-
-	currentBranch := gm.getCurrentBranch()
-	fmt.Printf("%sBranche (défaut '%s'): %s", ColorYellow, currentBranch, ColorReset)
-	branch := gm.getUserInput()
-	if branch == "" {
-		branch = currentBranch
+func (gm *GitManager) handleResetRevert() {
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🔄 RESET / REVERT%s\n", ColorBold, ColorRed, ColorReset)
+		fmt.Println(strings.Repeat("═", 30))
+		fmt.Println("1. Reset (déplacer HEAD)")
+		fmt.Println("2. Revert (créer un commit d'annulation)")
+		fmt.Println("3. Restaurer une sauvegarde (refs/gitman/backup)")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			gm.handleReset()
+		case "2":
+			gm.handleRevert()
+		case "3":
+			gm.restoreBackup()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
 	}
+}
 
-	fmt.Printf("%sForcer le push? (y/N): %s", ColorRed, ColorReset)
-	force := gm.getUserInput()
+func (gm *GitManager) handleReset() {
+	fmt.Printf("%sCommit cible (ex: HEAD~1, hash): %s", ColorYellow, ColorReset)
+	target := gm.getUserInput()
+	if target == "" {
+		fmt.Printf("%s❌ Cible requise!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", target+"^{commit}"); err != nil {
+		fmt.Printf("%s❌ Commit introuvable: %s%s\n", ColorRed, target, ColorReset)
+		gm.pause()
+		return
+	}
 
-	args := []string{"push", remote, branch}
-	if strings.ToLower(force) == "y" {
-		args = append(args, "--force")
+	fmt.Println("Types de reset:")
+	fmt.Println("1. --soft (ne touche pas à l'index ni à l'arbre de travail)")
+	fmt.Println("2. --mixed (défaut, reset l'index mais pas l'arbre de travail)")
+	fmt.Println("3. --hard (ATTENTION: perd les modifications locales)")
+
+	fmt.Printf("\n%sChoisissez un type de reset (défaut 2): %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+
+	resetType := "--mixed"
+	switch choice {
+	case "1":
+		resetType = "--soft"
+	case "3":
+		resetType = "--hard"
 	}
 
-	output, err := gm.runGitCommand(args...)
+	gm.previewReset(target, resetType)
+
+	fmt.Printf("\n%s⚠️  Confirmer le reset %s sur '%s'? (y/N): %s", ColorRed, resetType, target, ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" {
+		gm.pause()
+		return
+	}
+	if resetType == "--hard" && !gm.confirmProtectedAction(gm.getCurrentBranch(), "reset --hard") {
+		gm.pause()
+		return
+	}
+
+	backup, err := gm.createBackupRef("reset " + resetType + " " + target)
+	if err != nil {
+		fmt.Printf("%s❌ Impossible de créer la sauvegarde: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
+
+	output, err := gm.runGitCommand("reset", resetType, target)
 	if err != nil {
 		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
 	} else {
-		fmt.Printf("%s✅ Push terminé!%s\n", ColorGreen, ColorReset)
+		fmt.Printf("%s✅ Reset effectué!%s\n", ColorGreen, ColorReset)
 		fmt.Println(output)
+		fmt.Printf("%s💾 Sauvegarde: %s (Reset / Revert > Restaurer une sauvegarde)%s\n", ColorCyan, backup, ColorReset)
 	}
 	gm.pause()
 }
 
-// File Management
-func (gm *GitManager) printFileStatus(line string) {
-	if len(line) < 3 {
-		return
+// Crée une référence de sauvegarde refs/gitman/backup/<branche>/<date> avant une opération destructive.
+// Les modifications non commitées sont aussi sauvegardées (suffixe -worktree) via git stash create.
+func (gm *GitManager) createBackupRef(reason string) (string, error) {
+	branch := gm.getCurrentBranch()
+	if branch == "" || branch == "HEAD" {
+		branch = "detached"
+	}
+	ref := fmt.Sprintf("refs/gitman/backup/%s/%s", branch, time.Now().Format("20060102-150405"))
+	for i := 2; ; i++ {
+		if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", ref); err != nil {
+			break
+		}
+		ref = fmt.Sprintf("refs/gitman/backup/%s/%s-%d", branch, time.Now().Format("20060102-150405"), i)
 	}
 
-	status := line[:2]
-	file := line[3:]
+	if output, err := gm.runGitCommand("update-ref", "-m", "gitman: "+reason, ref, "HEAD"); err != nil {
+		return "", fmt.Errorf("%s", output)
+	}
+	if stash, err := gm.runGitCommand("stash", "create", "gitman: "+reason); err == nil && stash != "" {
+		gm.runGitCommand("update-ref", ref+"-worktree", stash)
+	}
+	return ref, nil
+}
 
-	switch status {
-	case "M ":
-		fmt.Printf("%s  M  %s%s (modifié, en stage)\n", ColorGreen, file, ColorReset)
-	case " M":
-		fmt.Printf("%s  M  %s%s (modifié)\n", ColorYellow, file, ColorReset)
-	case "A ":
-		fmt.Printf("%s  A  %s%s (ajouté)\n", ColorGreen, file, ColorReset)
-	case "D ":
-		fmt.Printf("%s  D  %s%s (supprimé)\n", ColorRed, file, ColorReset)
-	case "??":
-		fmt.Printf("%s  ?  %s%s (non suivi)\n", ColorRed, file, ColorReset)
-	case "R ":
-		fmt.Printf("%s  R  %s%s (renommé)\n", ColorCyan, file, ColorReset)
-	default:
-		fmt.Printf("%s  %s  %s%s\n", ColorWhite, status, file, ColorReset)
+// Affiche l'impact d'un reset: commits qui quittent la branche et fichiers affectés
+func (gm *GitManager) previewReset(target, mode string) {
+	branch := gm.getCurrentBranch()
+
+	leaving, _ := gm.runGitCommand("log", "--oneline", target+"..HEAD")
+	if leaving != "" {
+		fmt.Printf("\n%s📤 Commits qui quitteront '%s':%s\n%s\n", ColorRed, branch, ColorReset, leaving)
+		if pushed, _ := gm.runGitCommand("branch", "-r", "--contains", "HEAD"); pushed != "" {
+			fmt.Printf("%s⚠️  Ces commits sont déjà sur un remote: un push forcé sera nécessaire.%s\n", ColorYellow, ColorReset)
+		}
+	} else {
+		fmt.Printf("\n%s📤 Aucun commit ne quitte '%s'%s\n", ColorGreen, branch, ColorReset)
+	}
+	if arriving, _ := gm.runGitCommand("log", "--oneline", "HEAD.."+target); arriving != "" {
+		fmt.Printf("%s📥 Commits qui rejoindront '%s':%s\n%s\n", ColorBlue, branch, ColorReset, arriving)
+	}
+
+	if mode == "--hard" {
+		// Tout fichier suivi dont l'arbre de travail diffère de la cible perd ses changements
+		lost, _ := gm.runGitCommand("diff", "--name-status", target)
+		if lost != "" {
+			fmt.Printf("%s🗑️  Fichiers dont les modifications seront perdues:%s\n%s\n", ColorRed, ColorReset, lost)
+		}
+	} else {
+		kept, _ := gm.runGitCommand("diff", "--name-status", target, "HEAD")
+		if kept != "" {
+			where := "non indexées"
+			if mode == "--soft" {
+				where = "indexées"
+			}
+			fmt.Printf("%s📝 Changements conservés comme modifications %s:%s\n%s\n", ColorCyan, where, ColorReset, kept)
+		}
 	}
 }
 
-func (gm *GitManager) addFiles() {
-	status := gm.getGitStatus()
-	if status == "" {
-		fmt.Printf("%s✅ Aucun fichier à ajouter!%s\n", ColorGreen, ColorReset)
+// Liste les sauvegardes refs/gitman/backup et permet d'en restaurer ou d'en supprimer une
+func (gm *GitManager) restoreBackup() {
+	output, _ := gm.runGitCommand("for-each-ref", "--sort=-refname", "--format=%(refname)%09%(objectname:short)%09%(subject)", "refs/gitman/backup")
+	if output == "" {
+		fmt.Printf("%s✅ Aucune sauvegarde disponible%s\n", ColorGreen, ColorReset)
 		gm.pause()
 		return
 	}
 
-	fmt.Printf("%s📁 Fichiers disponibles:%s\n", ColorYellow, ColorReset)
-	lines := strings.Split(status, "\n")
-	for _, line := range lines {
-		if line != "" {
-			gm.printFileStatus(line)
+	var refs []string
+	fmt.Printf("%s💾 Sauvegardes:%s\n", ColorBlue, ColorReset)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 || strings.HasSuffix(fields[0], "-worktree") {
+			continue
+		}
+		refs = append(refs, fields[0])
+		name := strings.TrimPrefix(fields[0], "refs/gitman/backup/")
+		worktree := ""
+		if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", fields[0]+"-worktree"); err == nil {
+			worktree = fmt.Sprintf(" %s[+ modifications non commitées]%s", ColorCyan, ColorReset)
 		}
+		fmt.Printf("  %2d. %s%s%s %s%s%s %s%s\n", len(refs), ColorGreen, name, ColorReset, ColorYellow, fields[1], ColorReset, fields[2], worktree)
 	}
 
-	fmt.Println("\n1. Ajouter tous les fichiers")
-	fmt.Println("2. Ajouter des fichiers spécifiques")
-	fmt.Println("0. Annuler")
+	fmt.Printf("\n%sSauvegarde (numéro, 'd <n>' pour supprimer): %s", ColorYellow, ColorReset)
+	fields := strings.Fields(gm.getUserInput())
+	if len(fields) == 0 {
+		return
+	}
+	remove := fields[0] == "d" && len(fields) == 2
+	if remove {
+		fields = fields[1:]
+	}
+	index, err := strconv.Atoi(fields[0])
+	if err != nil || index < 1 || index > len(refs) {
+		fmt.Printf("%s❌ Numéro invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	ref := refs[index-1]
 
-	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
+	if remove {
+		gm.runGitCommand("update-ref", "-d", ref)
+		gm.runGitCommand("update-ref", "-d", ref+"-worktree")
+		fmt.Printf("%s✅ Sauvegarde supprimée%s\n", ColorGreen, ColorReset)
+		gm.pause()
+		return
+	}
 
-	switch choice {
-	case "1":
-		output, err := gm.runGitCommand("add", ".")
-		if err != nil {
-			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else {
-			fmt.Printf("%s✅ Tous les fichiers ajoutés!%s\n", ColorGreen, ColorReset)
-		}
-	case "2":
-		fmt.Printf("%sFichiers à ajouter (séparés par des espaces): %s", ColorYellow, ColorReset)
-		files := gm.getUserInput()
-		if files != "" {
-			fileList := strings.Fields(files)
-			for _, file := range fileList {
-				output, err := gm.runGitCommand("add", file)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
-				} else {
-					fmt.Printf("%s✅ '%s' ajouté!%s\n", ColorGreen, file, ColorReset)
-				}
-			}
+	gm.previewReset(ref, "--hard")
+	fmt.Printf("\n%sRestaurer '%s' sur cette sauvegarde (reset --hard)? (y/N): %s", ColorRed, gm.getCurrentBranch(), ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" || !gm.confirmProtectedAction(gm.getCurrentBranch(), "reset --hard") {
+		gm.pause()
+		return
+	}
+
+	commit, _ := gm.runGitCommand("rev-parse", ref)
+	worktree, _ := gm.runGitCommand("rev-parse", "--verify", "--quiet", ref+"-worktree")
+
+	// L'état actuel est lui-même sauvegardé pour pouvoir annuler la restauration
+	if backup, err := gm.createBackupRef("avant restauration"); err == nil {
+		fmt.Printf("%s💾 État actuel sauvegardé: %s%s\n", ColorCyan, backup, ColorReset)
+	}
+	if output, err := gm.runGitCommand("reset", "--hard", commit); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
+	if worktree != "" {
+		if output, err := gm.runGitCommand("stash", "apply", worktree); err != nil {
+			fmt.Printf("%s⚠️  Modifications non commitées non restaurées: %s%s\n", ColorYellow, output, ColorReset)
 		}
 	}
+	fmt.Printf("%s✅ Sauvegarde restaurée!%s\n", ColorGreen, ColorReset)
 	gm.pause()
 }
 
-func (gm *GitManager) unstageFiles() {
-	staged, _ := gm.runGitCommand("diff", "--cached", "--name-only")
-	if staged == "" {
-		fmt.Printf("%s✅ Aucun fichier en stage!%s\n", ColorGreen, ColorReset)
+func (gm *GitManager) handleRevert() {
+	fmt.Printf("%sCommit ou plage à annuler (ex: abc123, HEAD~3..HEAD): %s", ColorYellow, ColorReset)
+	target := gm.getUserInput()
+	if target == "" {
+		fmt.Printf("%s❌ Cible requise!%s\n", ColorRed, ColorReset)
 		gm.pause()
 		return
 	}
 
-	fmt.Printf("%s📁 Fichiers en stage:%s\n", ColorYellow, ColorReset)
-	fmt.Println(staged)
-
-	fmt.Println("\n1. Retirer tous les fichiers du staging")
-	fmt.Println("2. Retirer des fichiers spécifiques")
-	fmt.Println("0. Annuler")
+	// Du plus récent au plus ancien, comme git revert sur une plage; les commits
+	// apportés par un merge sont annulés avec le merge lui-même (--first-parent)
+	args := []string{"rev-list", "--first-parent", target}
+	if !strings.Contains(target, "..") {
+		args = []string{"rev-list", "-n", "1", target}
+	}
+	output, err := gm.runGitCommand(args...)
+	if err != nil || output == "" {
+		fmt.Printf("%s❌ Aucun commit à annuler: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
+	commits := strings.Split(output, "\n")
 
-	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
+	fmt.Printf("\n%s↩️  Commits à annuler:%s\n", ColorBlue, ColorReset)
+	mainlines := make(map[string]string)
+	for _, commit := range commits {
+		summary, _ := gm.runGitCommand("log", "-1", "--format=%h %s", commit)
+		fmt.Printf("  %s\n", summary)
 
-	switch choice {
-	case "1":
-		output, err := gm.runGitCommand("reset", "HEAD")
-		if err != nil {
-			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else {
-			fmt.Printf("%s✅ Tous les fichiers retirés du staging!%s\n", ColorGreen, ColorReset)
-		}
-	case "2":
-		fmt.Printf("%sFichiers à retirer (séparés par des espaces): %s", ColorYellow, ColorReset)
-		files := gm.getUserInput()
-		if files != "" {
-			fileList := strings.Fields(files)
-			for _, file := range fileList {
-				output, err := gm.runGitCommand("reset", "HEAD", "--", file)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
-				} else {
-					fmt.Printf("%s✅ '%s' retiré du staging!%s\n", ColorGreen, file, ColorReset)
-				}
+		parents, _ := gm.runGitCommand("log", "-1", "--format=%P", commit)
+		if parentList := strings.Fields(parents); len(parentList) > 1 {
+			fmt.Printf("  %s🔀 Commit de merge: choisissez le parent principal (mainline)%s\n", ColorPurple, ColorReset)
+			for i, parent := range parentList {
+				parentSummary, _ := gm.runGitCommand("log", "-1", "--format=%h %s", parent)
+				fmt.Printf("     %d. %s\n", i+1, parentSummary)
 			}
+			fmt.Printf("  %sParent principal (défaut 1): %s", ColorYellow, ColorReset)
+			mainline := gm.getUserInput()
+			if n, err := strconv.Atoi(mainline); err != nil || n < 1 || n > len(parentList) {
+				mainline = "1"
+			}
+			mainlines[commit] = mainline
 		}
 	}
-	gm.pause()
-}
 
-func (gm *GitManager) showDiff() {
-	for {
-		gm.clearScreen()
-		fmt.Println("1. Voir les changements non stagés")
-		fmt.Println("2. Voir les changements stagés")
-		fmt.Println("3. Voir les changements d'un fichier spécifique")
-		fmt.Println("4. Comparer deux commits")
-		fmt.Println("0. Retour")
+	noCommit := false
+	if len(commits) > 1 {
+		fmt.Printf("\n%sRegrouper les annulations en un seul commit (--no-commit)? (y/N): %s", ColorYellow, ColorReset)
+		noCommit = strings.ToLower(gm.getUserInput()) == "y"
+	} else {
+		fmt.Printf("\n%sPréparer sans commiter (--no-commit, pour regrouper plus tard)? (y/N): %s", ColorYellow, ColorReset)
+		noCommit = strings.ToLower(gm.getUserInput()) == "y"
+	}
 
-		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
+	// Un seul git revert par suite de commits partageant le même mainline: en cas de conflit,
+	// --continue poursuit la séquence au lieu d'abandonner le reste de la plage
+	var batches [][]string
+	for i, commit := range commits {
+		if i == 0 || mainlines[commit] != mainlines[commits[i-1]] {
+			batches = append(batches, nil)
+		}
+		batches[len(batches)-1] = append(batches[len(batches)-1], commit)
+	}
 
-		switch choice {
-		case "1":
-			// Ajout de l'option --color=always pour forcer la coloration
-			output, _ := gm.runGitCommand("diff", "--color=always")
-			if output == "" {
-				fmt.Printf("%s✅ Aucun changement non stagé!%s\n", ColorGreen, ColorReset)
-			} else {
-				fmt.Printf("%s📊 Changements non stagés:%s\n", ColorBlue, ColorReset)
-				fmt.Println(output)
-			}
-			gm.pause()
-		case "2":
-			// Ajout de l'option --color=always pour forcer la coloration
-			output, _ := gm.runGitCommand("diff", "--cached", "--color=always")
-			if output == "" {
-				fmt.Printf("%s✅ Aucun changement stagé!%s\n", ColorGreen, ColorReset)
-			} else {
-				fmt.Printf("%s📊 Changements stagés:%s\n", ColorBlue, ColorReset)
-				fmt.Println(output)
+	for i := 0; i < len(batches); i++ {
+		batch := batches[i]
+		revertArgs := []string{"revert", "--no-edit"}
+		if noCommit {
+			revertArgs = []string{"revert", "--no-commit"}
+		}
+		if mainline, ok := mainlines[batch[0]]; ok {
+			revertArgs = append(revertArgs, "-m", mainline)
+		}
+		output, err := gm.runGitCommand(append(revertArgs, batch...)...)
+		if err == nil {
+			continue
+		}
+
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		var remaining []string
+		for _, next := range batches[i+1:] {
+			remaining = append(remaining, next...)
+		}
+		if len(remaining) > 0 {
+			fmt.Printf("%s⚠️  Commits pas encore annulés (après ce lot):%s\n", ColorYellow, ColorReset)
+			for _, commit := range remaining {
+				summary, _ := gm.runGitCommand("log", "-1", "--format=%h %s", commit)
+				fmt.Printf("  %s\n", summary)
 			}
+		}
+		if gm.getOperationInProgress() == "" {
 			gm.pause()
-		case "3":
-			fmt.Printf("%sNom du fichier: %s", ColorYellow, ColorReset)
-			filename := gm.getUserInput()
-			if filename != "" {
-				// Ajout de l'option --color=always pour forcer la coloration
-				output, err := gm.runGitCommand("diff", "--color=always", "--", filename)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-				} else {
-					fmt.Printf("%s📊 Changements dans '%s':%s\n", ColorBlue, filename, ColorReset)
-					fmt.Println(output)
-				}
-			}
-			gm.pause()
-		case "4":
-			fmt.Printf("%sPremier commit: %s", ColorYellow, ColorReset)
-			commit1 := gm.getUserInput()
-			fmt.Printf("%sDeuxième commit: %s", ColorYellow, ColorReset)
-			commit2 := gm.getUserInput()
+			return
+		}
 
-			if commit1 != "" && commit2 != "" {
-				// Ajout de l'option --color=always pour forcer la coloration
-				output, err := gm.runGitCommand("diff", "--color=always", commit1, commit2)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-				} else {
-					fmt.Printf("%s📊 Différences entre '%s' et '%s':%s\n", ColorBlue, commit1, commit2, ColorReset)
-					fmt.Println(output)
-				}
-			}
-			gm.pause()
-		case "0":
+		fmt.Printf("%s💡 Après résolution, --continue annule les commits restants de ce lot.%s\n", ColorCyan, ColorReset)
+		gm.pause()
+		gm.handleOperationInProgress()
+		if len(remaining) == 0 {
 			return
-		default:
-			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
-			gm.pause()
 		}
+		if gm.getOperationInProgress() == "" {
+			fmt.Printf("%sReprendre l'annulation des %d commit(s) restant(s)? (y/N): %s", ColorYellow, len(remaining), ColorReset)
+			if strings.ToLower(gm.getUserInput()) == "y" {
+				continue
+			}
+		}
+		short := make([]string, len(remaining))
+		for j, commit := range remaining {
+			short[j] = commit[:7]
+		}
+		fmt.Printf("%s💡 Pour les annuler plus tard: git revert %s%s\n", ColorCyan, strings.Join(short, " "), ColorReset)
+		gm.pause()
+		return
 	}
-}
-
-func (gm *GitManager) manageGitignore() {
-	gitignorePath := filepath.Join(gm.currentPath, ".gitignore")
 
-	for {
-		gm.clearScreen()
-		fmt.Println("1. Voir le contenu de .gitignore")
-		fmt.Println("2. Ajouter des patterns à .gitignore")
-		fmt.Println("3. Créer un .gitignore basique")
-		fmt.Println("0. Retour")
+	if !noCommit {
+		fmt.Printf("%s✅ %d commit(s) annulé(s)!%s\n", ColorGreen, len(commits), ColorReset)
+		result, _ := gm.runGitCommand("log", "--oneline", fmt.Sprintf("-%d", len(commits)))
+		fmt.Println(result)
+		gm.pause()
+		return
+	}
 
-		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
+	if len(commits) == 1 {
+		fmt.Printf("%s✅ Annulation préparée dans l'index: ajoutez d'autres reverts puis commitez.%s\n", ColorGreen, ColorReset)
+		gm.pause()
+		return
+	}
 
-		switch choice {
-		case "1":
-			content, err := os.ReadFile(gitignorePath)
-			if err != nil {
-				fmt.Printf("%s❌ Fichier .gitignore introuvable ou erreur de lecture%s\n", ColorRed, ColorReset)
-			} else {
-				fmt.Printf("%s📄 Contenu de .gitignore:%s\n", ColorBlue, ColorReset)
-				fmt.Println(string(content))
-			}
-			gm.pause()
-		case "2":
-			fmt.Printf("%sPatterns à ajouter (un par ligne, ligne vide pour terminer):%s\n", ColorYellow, ColorReset)
-			var patterns []string
-			for {
-				fmt.Print("> ")
-				line := gm.getUserInput()
-				if line == "" {
-					break
-				}
-				patterns = append(patterns, line)
-			}
+	defaultMessage := fmt.Sprintf("Revert %d commits (%s)", len(commits), target)
+	fmt.Printf("%sMessage du commit (défaut: %s): %s", ColorYellow, defaultMessage, ColorReset)
+	message := gm.getUserInput()
+	if message == "" {
+		message = defaultMessage + "\n\nThis reverts commits:"
+		for _, commit := range commits {
+			summary, _ := gm.runGitCommand("log", "-1", "--format=%H %s", commit)
+			message += "\n- " + summary
+		}
+	}
+	if output, err := gm.runGitCommand("commit", "-m", message); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ %d commit(s) annulé(s) en un seul commit!%s\n", ColorGreen, len(commits), ColorReset)
+	}
+	gm.pause()
+}
 
-			if len(patterns) > 0 {
-				file, err := os.OpenFile(gitignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur lors de l'ouverture du fichier: %s%s\n", ColorRed, err.Error(), ColorReset)
-				} else {
-					defer file.Close()
-					for _, pattern := range patterns {
-						_, _ = file.WriteString(pattern + "\n")
-					}
-					fmt.Printf("%s✅ Patterns ajoutés à .gitignore!%s\n", ColorGreen, ColorReset)
-				}
-			}
-			gm.pause()
-		case "3":
-			basicGitignore := `# Fichiers de compilation
-*.o
-*.so
-*.dylib
-*.exe
+// Secret Scanning
+// Résultat du scan pré-commit
+type scanFinding struct {
+	path   string
+	line   int
+	kind   string
+	detail string
+}
 
-# Fichiers de debug
-*.dSYM/
-*.pdb
+var secretPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"Clé d'accès AWS", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"Clé secrète AWS", regexp.MustCompile(`(?i)aws.{0,20}(secret|private).{0,20}[=:]\s*['"]?[0-9a-zA-Z/+]{40}\b`)},
+	{"Clé privée", regexp.MustCompile(`-----BEGIN ((RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY( BLOCK)?-----`)},
+	{"Token GitHub", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"Token Slack", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"Mot de passe / secret en clair", regexp.MustCompile(`(?i)(password|passwd|secret|token|api[_-]?key)["']?\s*[:=]\s*['"][^'"\s]{8,}['"]`)},
+}
 
-# Fichiers temporaires
-*.tmp
-*.temp
-*~
-.DS_Store
-Thumbs.db
+var (
+	entropyToken = regexp.MustCompile(`[A-Za-z0-9+/=_-]{24,}`)
+	hunkNewStart = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)`)
+)
 
-# Répertoires de dépendances
-node_modules/
-vendor/
+// Fichiers sensibles par nom (motifs filepath.Match sur le nom de base)
+var sensitiveFiles = []string{".env", ".env.*", "*.pem", "*.key", "*.p12", "*.pfx", "*.keystore", "*.jks", "id_rsa", "id_dsa", "id_ecdsa", "id_ed25519", ".npmrc", ".pypirc", "credentials.json"}
 
-# Fichiers de configuration locale
-.env
-.env.local
-config.local.*
+// Entropie de Shannon (bits par caractère)
+func shannonEntropy(value string) float64 {
+	counts := make(map[rune]int)
+	for _, char := range value {
+		counts[char]++
+	}
+	entropy := 0.0
+	length := float64(len(value))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
 
-# Logs
-*.log
-logs/
+// Masque un secret pour l'affichage du rapport
+func maskSecret(value string) string {
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:4] + strings.Repeat("*", 8) + value[len(value)-2:]
+}
 
-# Fichiers d'IDE
-.vscode/
-.idea/
-*.swp
-*.swo
-`
-			err := os.WriteFile(gitignorePath, []byte(basicGitignore), 0644)
-			if err != nil {
-				fmt.Printf("%s❌ Erreur lors de la création: %s%s\n", ColorRed, err.Error(), ColorReset)
-			} else {
-				fmt.Printf("%s✅ .gitignore basique créé!%s\n", ColorGreen, ColorReset)
-			}
-			gm.pause()
-		case "0":
-			return
-		default:
-			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
-			gm.pause()
+// Convertit une taille du type "5M", "500k" ou "1048576" en octets
+func parseSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	// Ordre fixe: les suffixes longs avant "B" seul
+	units := []struct {
+		suffix string
+		factor int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}}
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSuffix(value, unit.suffix)
+			multiplier = unit.factor
+			break
 		}
 	}
+	size, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	return size * multiplier, err
 }
 
-func (gm *GitManager) restoreFiles() {
-	status := gm.getGitStatus()
-	if status == "" {
-		fmt.Printf("%s✅ Aucun fichier à restaurer!%s\n", ColorGreen, ColorReset)
-		gm.pause()
-		return
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f Mo", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f Ko", float64(size)/(1<<10))
 	}
+	return fmt.Sprintf("%d o", size)
+}
 
-	fmt.Printf("%s📁 Fichiers modifiés:%s\n", ColorYellow, ColorReset)
-	lines := strings.Split(status, "\n")
-	for _, line := range lines {
-		if line != "" {
-			gm.printFileStatus(line)
+// Un chemin est ignoré s'il correspond à un motif de gitman.scanAllowlist (chemin complet ou nom de base)
+func (gm *GitManager) isScanAllowed(path string, allowlist []string) bool {
+	for _, pattern := range allowlist {
+		if matched, _ := filepath.Match(pattern, path); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+			return true
+		}
+		if strings.HasSuffix(pattern, "/") && strings.HasPrefix(path, pattern) {
+			return true
 		}
 	}
+	return false
+}
 
-	fmt.Println("\n1. Restaurer tous les fichiers modifiés")
-	fmt.Println("2. Restaurer des fichiers spécifiques")
-	fmt.Println("0. Annuler")
-
-	fmt.Printf("\n%s⚠️  ATTENTION: Cette action va perdre les modifications non commitées!%s\n", ColorRed, ColorReset)
-	fmt.Printf("%sChoisissez une option: %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
+// Analyse les changements en stage: secrets, fichiers sensibles, gros fichiers et binaires
+func (gm *GitManager) scanStagedChanges() []scanFinding {
+	var findings []scanFinding
+	allowlist := gm.getGitConfigList("gitman.scanAllowlist", nil)
 
-	switch choice {
-	case "1":
-		fmt.Printf("%sÊtes-vous sûr? (y/N): %s", ColorRed, ColorReset)
-		confirm := gm.getUserInput()
-		if strings.ToLower(confirm) == "y" {
-			output, err := gm.runGitCommand("checkout", "--", ".")
-			if err != nil {
-				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-			} else {
-				fmt.Printf("%s✅ Tous les fichiers restaurés!%s\n", ColorGreen, ColorReset)
-			}
+	maxSize := int64(5 << 20)
+	if value := gm.getGitConfig("gitman.maxFileSize"); value != "" {
+		if size, err := parseSize(value); err == nil {
+			maxSize = size
 		}
-	case "2":
-		fmt.Printf("%sFichiers à restaurer (séparés par des espaces): %s", ColorYellow, ColorReset)
-		files := gm.getUserInput()
-		if files != "" {
-			fmt.Printf("%sÊtes-vous sûr? (y/N): %s", ColorRed, ColorReset)
-			confirm := gm.getUserInput()
-			if strings.ToLower(confirm) == "y" {
-				fileList := strings.Fields(files)
-				for _, file := range fileList {
-					output, err := gm.runGitCommand("checkout", "--", file)
-					if err != nil {
-						fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
-					} else {
-						fmt.Printf("%s✅ '%s' restauré!%s\n", ColorGreen, file, ColorReset)
-					}
-				}
-			}
+	}
+
+	// Noms sensibles, taille et binaires
+	// -z: chemins bruts, sans guillemets ni échappements
+	raw, _ := gm.runGitCommand("diff", "--cached", "--raw", "-z", "--no-abbrev", "--no-renames", "--diff-filter=ACMT")
+	numstat, _ := gm.runGitCommand("diff", "--cached", "--numstat", "-z", "--no-renames", "--diff-filter=ACMT")
+	binaries := make(map[string]bool)
+	for _, entry := range strings.Split(numstat, "\x00") {
+		if strings.HasPrefix(entry, "-\t-\t") {
+			binaries[strings.TrimPrefix(entry, "-\t-\t")] = true
 		}
 	}
-	gm.pause()
-}
 
-func (gm *GitManager) untrackFiles() {
-	fmt.Printf("%sFichiers à ne plus tracker (séparés par des espaces): %s", ColorYellow, ColorReset)
-	files := gm.getUserInput()
+	// Format -z: ":meta\x00chemin\x00" pour chaque fichier
+	rawEntries := strings.Split(raw, "\x00")
+	for i := 0; i+1 < len(rawEntries); i += 2 {
+		fields := strings.Fields(rawEntries[i])
+		path := rawEntries[i+1]
+		if len(fields) < 4 || gm.isScanAllowed(path, allowlist) {
+			continue
+		}
 
-	if files != "" {
-		fmt.Printf("%sGarder les fichiers localement? (Y/n): %s", ColorYellow, ColorReset)
-		keep := gm.getUserInput()
+		for _, pattern := range sensitiveFiles {
+			if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
+				findings = append(findings, scanFinding{path, 0, "Fichier sensible", "nom correspondant à " + pattern})
+				break
+			}
+		}
 
-		fileList := strings.Fields(files)
-		for _, file := range fileList {
-			var output string
-			var err error
+		sizeOutput, err := gm.runGitCommand("cat-file", "-s", fields[3])
+		size, _ := strconv.ParseInt(sizeOutput, 10, 64)
+		if err == nil && size > maxSize {
+			findings = append(findings, scanFinding{path, 0, "Fichier volumineux", fmt.Sprintf("%s (limite %s)", formatSize(size), formatSize(maxSize))})
+		}
+		if binaries[path] {
+			findings = append(findings, scanFinding{path, 0, "Fichier binaire", formatSize(size)})
+		}
+	}
 
-			if strings.ToLower(keep) == "n" {
-				output, err = gm.runGitCommand("rm", file)
-			} else {
-				output, err = gm.runGitCommand("rm", "--cached", file)
+	// Contenu ajouté
+	diff, _ := gm.rawDiff("diff", "--cached", "-U0", "--no-color", "--no-ext-diff", "--diff-filter=ACMT", "--dst-prefix=b/")
+	path, lineNumber := "", 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			path = strings.TrimPrefix(line, "+++ ")
+			// Chemins spéciaux cités façon C: "b/caf\303\251.txt"
+			if unquoted, err := strconv.Unquote(path); err == nil {
+				path = unquoted
+			}
+			path = strings.TrimPrefix(path, "b/")
+			continue
+		case strings.HasPrefix(line, "@@"):
+			if match := hunkNewStart.FindStringSubmatch(line); match != nil {
+				lineNumber, _ = strconv.Atoi(match[1])
 			}
+			continue
+		case !strings.HasPrefix(line, "+"):
+			continue
+		}
 
-			if err != nil {
-				fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
-			} else {
-				fmt.Printf("%s✅ '%s' retiré du tracking!%s\n", ColorGreen, file, ColorReset)
+		content := line[1:]
+		current := lineNumber
+		lineNumber++
+		if gm.isScanAllowed(path, allowlist) || strings.Contains(content, "gitman:allow") {
+			continue
+		}
+
+		found := false
+		for _, secret := range secretPatterns {
+			if match := secret.pattern.FindString(content); match != "" {
+				findings = append(findings, scanFinding{path, current, secret.kind, maskSecret(match)})
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		for _, token := range entropyToken.FindAllString(content, -1) {
+			if shannonEntropy(token) >= 4.5 {
+				findings = append(findings, scanFinding{path, current, "Token à forte entropie", maskSecret(token)})
+				break
 			}
 		}
 	}
-	gm.pause()
+	return findings
 }
 
-// Tag Management
-func (gm *GitManager) createTag() {
-	fmt.Printf("%sNom du tag: %s", ColorYellow, ColorReset)
-	tagName := gm.getUserInput()
+// Scan pré-commit: affiche le rapport et bloque sauf dérogation explicite; retourne true si le commit peut continuer
+func (gm *GitManager) checkStagedChanges() bool {
+	if gm.getGitConfig("gitman.secretScan") == "false" {
+		return true
+	}
 
-	if tagName == "" {
-		fmt.Printf("%s❌ Nom de tag requis!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+	findings := gm.scanStagedChanges()
+	if len(findings) == 0 {
+		return true
 	}
 
-	fmt.Printf("%sCommit à tagger (vide pour HEAD): %s", ColorYellow, ColorReset)
-	commit := gm.getUserInput()
+	fmt.Printf("\n%s%s🚨 SCAN PRÉ-COMMIT: %d problème(s) détecté(s)%s\n", ColorBold, ColorRed, len(findings), ColorReset)
+	fmt.Println(strings.Repeat("═", 50))
+	paths := []string{}
+	seen := make(map[string]bool)
+	for _, finding := range findings {
+		location := finding.path
+		if finding.line > 0 {
+			location = fmt.Sprintf("%s:%d", finding.path, finding.line)
+		}
+		fmt.Printf("  %s✗ %s%s  %s%s%s  %s\n", ColorRed, finding.kind, ColorReset, ColorYellow, location, ColorReset, finding.detail)
+		if !seen[finding.path] {
+			seen[finding.path] = true
+			paths = append(paths, finding.path)
+		}
+	}
+	fmt.Printf("\n%s💡 Faux positif? Ajoutez le chemin à gitman.scanAllowlist ou 'gitman:allow' sur la ligne.%s\n", ColorCyan, ColorReset)
 
-	var output string
-	var err error
+	fmt.Printf("\n%s❌ Commit bloqué.%s\n", ColorRed, ColorReset)
+	fmt.Println("1. Retirer les fichiers concernés du stage")
+	fmt.Println("2. Commiter malgré tout (dérogation)")
+	fmt.Println("0. Annuler le commit")
+	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
 
-	if commit == "" {
-		output, err = gm.runGitCommand("tag", tagName)
-	} else {
-		output, err = gm.runGitCommand("tag", tagName, commit)
+	switch gm.getUserInput() {
+	case "1":
+		for _, path := range paths {
+			if output, err := gm.runGitCommand("reset", "-q", "HEAD", "--", path); err != nil {
+				// Premier commit: pas de HEAD
+				if output, err = gm.runGitCommand("rm", "--cached", "-q", "--", path); err != nil {
+					fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, path, output, ColorReset)
+					continue
+				}
+			}
+			fmt.Printf("%s✅ '%s' retiré du stage%s\n", ColorGreen, path, ColorReset)
+		}
+		staged, _ := gm.runGitCommand("diff", "--cached", "--name-only")
+		if staged == "" {
+			fmt.Printf("%s📋 Plus aucun fichier en stage.%s\n", ColorYellow, ColorReset)
+			return false
+		}
+		return gm.checkStagedChanges()
+	case "2":
+		fmt.Printf("%sTapez 'ignorer' pour confirmer la dérogation: %s", ColorRed, ColorReset)
+		if gm.getUserInput() == "ignorer" {
+			fmt.Printf("%s⚠️  Dérogation acceptée%s\n", ColorYellow, ColorReset)
+			return true
+		}
+		fmt.Printf("%s❌ Confirmation incorrecte, commit annulé.%s\n", ColorRed, ColorReset)
 	}
+	return false
+}
 
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Tag '%s' créé!%s\n", ColorGreen, tagName, ColorReset)
+// Message Editing
+// Caractère de commentaire des messages (core.commentChar, défaut #)
+func (gm *GitManager) commentChar() string {
+	char := gm.getGitConfig("core.commentChar")
+	if char == "" || char == "auto" {
+		return "#"
 	}
-	gm.pause()
+	return char
 }
 
-func (gm *GitManager) createAnnotatedTag() {
-	fmt.Printf("%sNom du tag: %s", ColorYellow, ColorReset)
-	tagName := gm.getUserInput()
-
-	if tagName == "" {
-		fmt.Printf("%s❌ Nom de tag requis!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+// Nettoie un message comme git: lignes de commentaire retirées, espaces superflus et lignes vides multiples supprimés
+func (gm *GitManager) cleanupMessage(message string) string {
+	output, err := gm.runGitCommandWithInput(message, "stripspace", "--strip-comments")
+	if err != nil {
+		return strings.TrimSpace(message)
 	}
+	return output
+}
 
-	fmt.Printf("%sMessage du tag: %s", ColorYellow, ColorReset)
-	message := gm.getUserInput()
-
-	if message == "" {
-		fmt.Printf("%s❌ Message requis pour un tag annoté!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+// Compose le message dans l'éditeur de Git (GIT_EDITOR, core.editor, VISUAL, EDITOR)
+func (gm *GitManager) editMessageInEditor(initial string, comments []string) (string, error) {
+	editor, err := gm.runGitCommand("var", "GIT_EDITOR")
+	if err != nil || editor == "" {
+		return "", fmt.Errorf("aucun éditeur configuré")
 	}
 
-	fmt.Printf("%sCommit à tagger (vide pour HEAD): %s", ColorYellow, ColorReset)
-	commit := gm.getUserInput()
+	file, err := os.CreateTemp("", "gitman-message-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
 
-	var output string
-	var err error
+	content := initial
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += "\n"
+	char := gm.commentChar()
+	for _, comment := range comments {
+		content += strings.TrimRight(char+" "+comment, " ") + "\n"
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	file.Close()
 
-	if commit == "" {
-		output, err = gm.runGitCommand("tag", "-a", tagName, "-m", message)
-	} else {
-		output, err = gm.runGitCommand("tag", "-a", tagName, "-m", message, commit)
+	// Même convention que git: l'éditeur est interprété par le shell
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, file.Name())
+	cmd.Dir = gm.currentPath
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("l'éditeur '%s' a échoué: %v", editor, err)
 	}
 
+	edited, err := os.ReadFile(file.Name())
 	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Tag annoté '%s' créé!%s\n", ColorGreen, tagName, ColorReset)
+		return "", err
 	}
-	gm.pause()
+	return gm.cleanupMessage(string(edited)), nil
 }
 
-func (gm *GitManager) deleteTag() {
-	tags, _ := gm.runGitCommand("tag", "-l")
-	if tags == "" {
-		fmt.Printf("%s❌ Aucun tag à supprimer!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+// Saisie multi-ligne intégrée; une ligne contenant uniquement "." termine la saisie
+func (gm *GitManager) readMultilineMessage(initial string) string {
+	fmt.Printf("%sSaisissez le message (ligne '.' seule pour terminer, lignes '%s' ignorées):%s\n", ColorBlue, gm.commentChar(), ColorReset)
+	if initial != "" {
+		fmt.Printf("%sModèle:%s\n%s\n", ColorCyan, ColorReset, initial)
 	}
 
-	fmt.Printf("%s🏷️  Tags disponibles:%s\n", ColorBlue, ColorReset)
-	fmt.Println(tags)
-
-	fmt.Printf("\n%sNom du tag à supprimer: %s", ColorYellow, ColorReset)
-	tagName := gm.getUserInput()
-
-	if tagName == "" {
-		gm.pause()
-		return
+	var lines []string
+	for {
+		fmt.Print("| ")
+		// Lecture directe: fin de saisie sur EOF, et l'indentation des lignes est conservée
+		if !gm.scanner.Scan() {
+			break
+		}
+		line := strings.TrimRight(gm.scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "." {
+			break
+		}
+		lines = append(lines, line)
 	}
+	return gm.cleanupMessage(strings.Join(lines, "\n"))
+}
 
-	fmt.Printf("%s⚠️  Êtes-vous sûr de vouloir supprimer le tag '%s'? (y/N): %s", ColorRed, tagName, ColorReset)
-	confirm := gm.getUserInput()
+// Demande un message sur une ligne; ":e" ouvre l'éditeur, ":m" la saisie multi-ligne.
+// initial pré-remplit l'éditeur et comments y est ajouté en commentaires.
+// composed indique que le message vient de l'éditeur ou de la saisie multi-ligne.
+func (gm *GitManager) promptMessage(prompt, initial string, comments []string) (message string, composed bool) {
+	fmt.Printf("%s%s (:e éditeur, :m multi-ligne): %s", ColorYellow, prompt, ColorReset)
+	input := gm.getUserInput()
 
-	if strings.ToLower(confirm) == "y" {
-		output, err := gm.runGitCommand("tag", "-d", tagName)
+	switch input {
+	case ":e":
+		message, err := gm.editMessageInEditor(initial, comments)
 		if err != nil {
-			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else {
-			fmt.Printf("%s✅ Tag '%s' supprimé!%s\n", ColorGreen, tagName, ColorReset)
+			fmt.Printf("%s❌ %v%s\n", ColorRed, err, ColorReset)
+			return "", true
 		}
+		return message, true
+	case ":m":
+		return gm.readMultilineMessage(initial), true
 	}
-	gm.pause()
+	return input, false
 }
 
-func (gm *GitManager) showTagDetails() {
-	fmt.Printf("%sNom du tag: %s", ColorYellow, ColorReset)
-	tagName := gm.getUserInput()
-
-	if tagName == "" {
-		gm.pause()
-		return
+// Contenu du fichier commit.template (chemin relatif au dépôt, ~ accepté)
+func (gm *GitManager) commitTemplate() string {
+	path := gm.getGitConfig("commit.template")
+	if path == "" {
+		return ""
 	}
-
-	output, err := gm.runGitCommand("show", tagName)
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	} else if !filepath.IsAbs(path) {
+		// Comme git, un chemin relatif part de la racine du dépôt
+		root, err := gm.runGitCommand("rev-parse", "--show-toplevel")
+		if err != nil {
+			root = gm.currentPath
+		}
+		path = filepath.Join(root, path)
+	}
+	content, err := os.ReadFile(path)
 	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s🏷️  Détails du tag '%s':%s\n", ColorBlue, tagName, ColorReset)
-		fmt.Println(output)
+		return ""
 	}
-	gm.pause()
+	return string(content)
 }
 
-func (gm *GitManager) listTags() {
-	output, err := gm.runGitCommand("tag", "-l", "--sort=-version:refname")
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s🏷️  Tous les tags:%s\n", ColorBlue, ColorReset)
-		if output == "" {
-			fmt.Printf("%s❌ Aucun tag trouvé%s\n", ColorRed, ColorReset)
-		} else {
-			fmt.Println(output)
+// Commentaires ajoutés dans l'éditeur: instructions et fichiers en stage
+func (gm *GitManager) commitMessageComments() []string {
+	comments := []string{
+		fmt.Sprintf("Saisissez le message de commit. Les lignes commençant par '%s' sont ignorées,", gm.commentChar()),
+		"un message vide annule le commit.",
+		"",
+		"Modifications à commiter:",
+	}
+	staged, _ := gm.runGitCommand("diff", "--cached", "--name-status")
+	for _, line := range strings.Split(staged, "\n") {
+		if line != "" {
+			comments = append(comments, "\t"+strings.ReplaceAll(line, "\t", "   "))
 		}
 	}
-	gm.pause()
+	return comments
 }
 
-// Stash Management
-func (gm *GitManager) createStash() {
-	status := gm.getGitStatus()
-	if status == "" {
-		fmt.Printf("%s✅ Aucun changement à stasher!%s\n", ColorGreen, ColorReset)
-		gm.pause()
-		return
-	}
-
-	fmt.Printf("%sMessage pour le stash (optionnel): %s", ColorYellow, ColorReset)
-	message := gm.getUserInput()
+// Conventional Commits
+var conventionalHeader = regexp.MustCompile(`^([a-zA-Z]+)(\(([^()]*)\))?(!)?: (.*)$`)
 
-	fmt.Println("\n1. Stash normal (fichiers trackés)")
-	fmt.Println("2. Stash avec fichiers non trackés (-u)")
-	fmt.Println("3. Stash avec tous les fichiers (-a)")
-	fmt.Println("0. Annuler")
+func (gm *GitManager) getCommitTypes() []string {
+	return gm.getGitConfigList("gitman.commitTypes", []string{
+		"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert",
+	})
+}
 
-	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
+// Vérifie un message selon la spécification Conventional Commits; retourne la liste des problèmes
+func validateConventionalCommit(message string, types, scopes []string) []string {
+	var problems []string
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	header := lines[0]
 
-	var cmd []string
-	if message != "" {
-		cmd = []string{"stash", "push", "-m", message}
-	} else {
-		cmd = []string{"stash"}
+	match := conventionalHeader.FindStringSubmatch(header)
+	if match == nil {
+		return append(problems, "l'en-tête doit suivre le format 'type(scope)!: description'")
 	}
 
-	switch choice {
-	case "1":
-		// default command
-	case "2":
-		cmd = append(cmd, "-u")
-	case "3":
-		cmd = append(cmd, "-a")
-	case "0":
-		return
-	default:
-		fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+	commitType, scope, description := match[1], match[3], match[5]
+	if !containsString(types, commitType) {
+		problems = append(problems, fmt.Sprintf("type '%s' non autorisé (autorisés: %s)", commitType, strings.Join(types, ", ")))
+	}
+	if match[2] != "" && scope == "" {
+		problems = append(problems, "scope vide entre parenthèses")
+	}
+	if scope != "" && len(scopes) > 0 && !containsString(scopes, scope) {
+		problems = append(problems, fmt.Sprintf("scope '%s' non autorisé (autorisés: %s)", scope, strings.Join(scopes, ", ")))
 	}
+	if strings.TrimSpace(description) == "" {
+		problems = append(problems, "description manquante après ': '")
+	}
+	if len(header) > 100 {
+		problems = append(problems, fmt.Sprintf("en-tête trop long (%d caractères, max 100)", len(header)))
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		problems = append(problems, "une ligne vide doit séparer l'en-tête du corps")
+	}
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(strings.ToUpper(line), "BREAKING") && !strings.HasPrefix(line, "BREAKING CHANGE: ") && !strings.HasPrefix(line, "BREAKING-CHANGE: ") {
+			problems = append(problems, "le footer doit s'écrire 'BREAKING CHANGE: description'")
+		}
+	}
+	return problems
+}
 
-	output, err := gm.runGitCommand(cmd...)
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Stash créé!%s\n", ColorGreen, ColorReset)
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
 	}
-	gm.pause()
+	return false
 }
 
-func (gm *GitManager) applyStash() {
-	stashes, _ := gm.runGitCommand("stash", "list")
-	if stashes == "" {
-		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+// Valide le message si gitman.conventionalCommits est activé; retourne false si l'utilisateur annule
+func (gm *GitManager) checkCommitMessage(message string) bool {
+	if gm.getGitConfig("gitman.conventionalCommits") != "true" {
+		return true
 	}
 
-	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
-	fmt.Println(stashes)
+	problems := validateConventionalCommit(message, gm.getCommitTypes(), gm.getGitConfigList("gitman.commitScopes", nil))
+	if len(problems) == 0 {
+		return true
+	}
 
-	fmt.Printf("\n%sIndex du stash à appliquer (0 pour le plus récent): %s", ColorYellow, ColorReset)
-	indexStr := gm.getUserInput()
+	fmt.Printf("%s⚠️  Message non conforme à Conventional Commits:%s\n", ColorYellow, ColorReset)
+	for _, problem := range problems {
+		fmt.Printf("   %s✗%s %s\n", ColorRed, ColorReset, problem)
+	}
+	fmt.Printf("%sCommiter quand même? (y/N): %s", ColorYellow, ColorReset)
+	return strings.ToLower(gm.getUserInput()) == "y"
+}
 
-	index := 0
-	if indexStr != "" {
-		if i, err := strconv.Atoi(indexStr); err == nil {
-			index = i
+// Lit des lignes jusqu'à une ligne vide
+func (gm *GitManager) readLines() []string {
+	var lines []string
+	for {
+		fmt.Print("> ")
+		line := gm.getUserInput()
+		if line == "" {
+			return lines
 		}
+		lines = append(lines, line)
 	}
+}
 
-	fmt.Println("\n1. Appliquer et garder le stash (apply)")
-	fmt.Println("2. Appliquer et supprimer le stash (pop)")
-	fmt.Println("0. Annuler")
-
-	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
-
-	var output string
-	var err error
-	stashRef := fmt.Sprintf("stash@{%d}", index)
+// Assistant guidé: type, scope, breaking change, description, corps et footers
+func (gm *GitManager) conventionalCommitWizard() string {
+	types := gm.getCommitTypes()
+	scopes := gm.getGitConfigList("gitman.commitScopes", nil)
 
-	switch choice {
-	case "1":
-		output, err = gm.runGitCommand("stash", "apply", stashRef)
-	case "2":
-		output, err = gm.runGitCommand("stash", "pop", stashRef)
-	case "0":
-		return
-	default:
-		fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+	fmt.Printf("\n%s%s🧙 ASSISTANT CONVENTIONAL COMMITS%s\n", ColorBold, ColorCyan, ColorReset)
+	for i, t := range types {
+		fmt.Printf("  %2d. %s\n", i+1, t)
+	}
+	fmt.Printf("%sType (numéro ou nom, vide pour annuler): %s", ColorYellow, ColorReset)
+	typeChoice := gm.getUserInput()
+	if typeChoice == "" {
+		return ""
+	}
+	commitType := typeChoice
+	if i, err := strconv.Atoi(typeChoice); err == nil && i >= 1 && i <= len(types) {
+		commitType = types[i-1]
 	}
 
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Stash appliqué!%s\n", ColorGreen, ColorReset)
+	if len(scopes) > 0 {
+		fmt.Printf("%sScopes autorisés: %s%s\n", ColorBlue, strings.Join(scopes, ", "), ColorReset)
 	}
-	gm.pause()
-}
+	fmt.Printf("%sScope (optionnel): %s", ColorYellow, ColorReset)
+	scope := gm.getUserInput()
 
-func (gm *GitManager) showStash() {
-	stashes, _ := gm.runGitCommand("stash", "list")
-	if stashes == "" {
-		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+	fmt.Printf("%sChangement cassant (breaking change)? (y/N): %s", ColorYellow, ColorReset)
+	breaking := strings.ToLower(gm.getUserInput()) == "y"
+	breakingDescription := ""
+	if breaking {
+		fmt.Printf("%sDescription du changement cassant: %s", ColorYellow, ColorReset)
+		breakingDescription = gm.getUserInput()
 	}
 
-	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
-	fmt.Println(stashes)
+	fmt.Printf("%sDescription courte (impératif, sans point final): %s", ColorYellow, ColorReset)
+	subject := strings.TrimSuffix(gm.getUserInput(), ".")
 
-	fmt.Printf("\n%sIndex du stash à voir (0 pour le plus récent): %s", ColorYellow, ColorReset)
-	indexStr := gm.getUserInput()
+	fmt.Printf("%sCorps du message (ligne vide pour terminer):%s\n", ColorYellow, ColorReset)
+	body := gm.readLines()
 
-	index := 0
-	if indexStr != "" {
-		if i, err := strconv.Atoi(indexStr); err == nil {
-			index = i
-		}
+	fmt.Printf("%sFooters, ex: 'Refs: #123' (ligne vide pour terminer):%s\n", ColorYellow, ColorReset)
+	footers := gm.readLines()
+
+	header := commitType
+	if scope != "" {
+		header += "(" + scope + ")"
+	}
+	if breaking {
+		header += "!"
 	}
+	message := header + ": " + subject
 
-	stashRef := fmt.Sprintf("stash@{%d}", index)
-	output, err := gm.runGitCommand("stash", "show", "-p", stashRef)
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s🗂️  Contenu du stash %s:%s\n", ColorBlue, stashRef, ColorReset)
-		fmt.Println(output)
+	if len(body) > 0 {
+		message += "\n\n" + strings.Join(body, "\n")
 	}
-	gm.pause()
+	if breakingDescription != "" {
+		footers = append([]string{"BREAKING CHANGE: " + breakingDescription}, footers...)
+	}
+	if len(footers) > 0 {
+		message += "\n\n" + strings.Join(footers, "\n")
+	}
+
+	fmt.Printf("\n%s📝 Message généré:%s\n%s\n", ColorBlue, ColorReset, message)
+	if problems := validateConventionalCommit(message, types, scopes); len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Printf("   %s✗%s %s\n", ColorRed, ColorReset, problem)
+		}
+	}
+	fmt.Printf("\n%sUtiliser ce message? (y/N): %s", ColorYellow, ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" {
+		return ""
+	}
+	return message
 }
 
-func (gm *GitManager) dropStash() {
-	stashes, _ := gm.runGitCommand("stash", "list")
-	if stashes == "" {
-		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+// Demande un message de commit: saisie libre (validée) ou assistant guidé; "" si annulé
+func (gm *GitManager) promptCommitMessage() string {
+	fmt.Printf("%s(Entrée sans message pour l'assistant Conventional Commits)%s\n", ColorCyan, ColorReset)
+	template := gm.commitTemplate()
+	message, composed := gm.promptMessage("Message de commit", template, gm.commitMessageComments())
+	if message == "" && !composed {
+		return gm.conventionalCommitWizard()
+	}
+	// Comme git: un modèle non modifié équivaut à un message vide
+	if message == "" || (template != "" && message == gm.cleanupMessage(template)) {
+		return ""
 	}
 
-	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
-	fmt.Println(stashes)
+	if !gm.checkCommitMessage(message) {
+		return ""
+	}
+	return message
+}
 
-	fmt.Printf("\n%sIndex du stash à supprimer (0 pour le plus récent): %s", ColorYellow, ColorReset)
-	indexStr := gm.getUserInput()
+// Signing
+// Statut de signature (%G?) -> symbole, couleur et libellé
+type signatureStatus struct {
+	symbol string
+	color  string
+	label  string
+}
 
-	index := 0
-	if indexStr != "" {
-		if i, err := strconv.Atoi(indexStr); err == nil {
-			index = i
-		}
+var signatureStatuses = map[string]signatureStatus{
+	"G": {"✔", ColorGreen, "signature valide"},
+	"U": {"?", ColorYellow, "signature valide, confiance inconnue"},
+	"X": {"⌛", ColorYellow, "signature expirée"},
+	"Y": {"⌛", ColorYellow, "signature faite avec une clé expirée"},
+	"R": {"✗", ColorRed, "signature faite avec une clé révoquée"},
+	"B": {"✗", ColorRed, "signature invalide"},
+	"E": {"!", ColorYellow, "signature non vérifiable (clé absente)"},
+	"N": {"·", ColorWhite, "non signé"},
+}
+
+// Colonne de signature d'une ligne d'historique
+func signatureBadge(code string) string {
+	status, ok := signatureStatuses[code]
+	if !ok {
+		status = signatureStatuses["E"]
 	}
+	return status.color + status.symbol + ColorReset
+}
 
-	stashRef := fmt.Sprintf("stash@{%d}", index)
+func signatureLabel(code string) string {
+	status, ok := signatureStatuses[code]
+	if !ok {
+		status = signatureStatuses["E"]
+	}
+	return status.color + status.label + ColorReset
+}
 
-	fmt.Printf("%s⚠️  Êtes-vous sûr de vouloir supprimer le stash %s? (y/N): %s", ColorRed, stashRef, ColorReset)
-	confirm := gm.getUserInput()
+// Lance git log avec le format donné précédé de la colonne de signature (%G?), en conservant le graphe éventuel.
+// Un champ final séparé par %x1f (ex: %GS) est affiché comme signataire.
+func (gm *GitManager) signedLog(format string, args ...string) (string, error) {
+	output, err := gm.runGitCommand(append([]string{"log", "--pretty=format:%x1f%G?%x1f" + format}, args...)...)
+	if err != nil {
+		return output, err
+	}
 
-	if strings.ToLower(confirm) == "y" {
-		output, err := gm.runGitCommand("stash", "drop", stashRef)
-		if err != nil {
-			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else {
-			fmt.Printf("%s✅ Stash %s supprimé!%s\n", ColorGreen, stashRef, ColorReset)
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		parts := strings.SplitN(line, "\x1f", 3)
+		if len(parts) != 3 {
+			continue
 		}
+		rest, signer, _ := strings.Cut(parts[2], "\x1f")
+		if signer != "" {
+			rest += fmt.Sprintf(" %s[%s]%s", ColorCyan, signer, ColorReset)
+		}
+		lines[i] = parts[0] + signatureBadge(parts[1]) + " " + rest
 	}
-	gm.pause()
+	return strings.Join(lines, "\n"), nil
 }
 
-func (gm *GitManager) clearStashes() {
-	stashes, _ := gm.runGitCommand("stash", "list")
-	if stashes == "" {
-		fmt.Printf("%s❌ Aucun stash à supprimer!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+// Statut de signature d'un tag: N (non signé / léger), G (valide), U, B ou E
+func (gm *GitManager) tagSignatureStatus(tag string) string {
+	signature, _ := gm.runGitCommand("for-each-ref", "--format=%(contents:signature)", "refs/tags/"+tag)
+	if signature == "" {
+		return "N"
 	}
 
-	fmt.Printf("%s🗂️  Stashes actuels:%s\n", ColorBlue, ColorReset)
-	fmt.Println(stashes)
+	output, err := gm.runGitCommand("verify-tag", "--raw", tag)
+	switch {
+	case err == nil && (strings.Contains(output, "TRUST_UNDEFINED") || strings.Contains(output, "TRUST_NEVER")):
+		return "U"
+	case err == nil:
+		return "G"
+	case strings.Contains(output, "BADSIG") || strings.Contains(strings.ToLower(output), "bad signature"):
+		return "B"
+	}
+	return "E"
+}
 
-	fmt.Printf("\n%s⚠️  Êtes-vous sûr de vouloir supprimer TOUS les stashes? (y/N): %s", ColorRed, ColorReset)
-	confirm := gm.getUserInput()
+func (gm *GitManager) handleSigning() {
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🔏 SIGNATURE DES COMMITS ET TAGS%s\n", ColorBold, ColorPurple, ColorReset)
+		fmt.Println(strings.Repeat("═", 35))
 
-	if strings.ToLower(confirm) == "y" {
-		output, err := gm.runGitCommand("stash", "clear")
-		if err != nil {
-			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else {
-			fmt.Printf("%s✅ Tous les stashes supprimés!%s\n", ColorGreen, ColorReset)
+		format := gm.getGitConfig("gpg.format")
+		if format == "" {
+			format = "openpgp"
+		}
+		key := gm.getGitConfig("user.signingkey")
+		if key == "" {
+			key = "(par défaut selon l'email)"
+		}
+		fmt.Printf("Format:           %s%s%s\n", ColorCyan, format, ColorReset)
+		fmt.Printf("Clé:              %s%s%s\n", ColorCyan, key, ColorReset)
+		fmt.Printf("Commits signés:   %s\n", gm.onOff(gm.getGitConfig("commit.gpgSign") == "true"))
+		fmt.Printf("Tags signés:      %s\n", gm.onOff(gm.getGitConfig("tag.gpgSign") == "true"))
+		if format == "ssh" {
+			signers := gm.getGitConfig("gpg.ssh.allowedSignersFile")
+			if signers == "" {
+				signers = ColorYellow + "(non configuré: vérification impossible)" + ColorReset
+			}
+			fmt.Printf("Signataires SSH:  %s\n", signers)
+		}
+
+		fmt.Println()
+		fmt.Println("1. Activer / désactiver la signature (ce dépôt)")
+		fmt.Println("2. Choisir le format (GPG / SSH / X.509)")
+		fmt.Println("3. Définir la clé de signature")
+		fmt.Println("4. Fichier des signataires autorisés (SSH)")
+		fmt.Println("5. Tester la signature")
+		fmt.Println("6. Rapport de vérification d'une plage de commits")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			gm.toggleSigning()
+		case "2":
+			gm.chooseSigningFormat()
+		case "3":
+			gm.chooseSigningKey()
+		case "4":
+			gm.configureAllowedSigners()
+		case "5":
+			gm.testSigning()
+		case "6":
+			gm.verifySignatures()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
 		}
 	}
-	gm.pause()
 }
 
-func (gm *GitManager) stashToBranch() {
-	stashes, _ := gm.runGitCommand("stash", "list")
-	if stashes == "" {
-		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
-		gm.pause()
-		return
+func (gm *GitManager) onOff(enabled bool) string {
+	if enabled {
+		return ColorGreen + "activé" + ColorReset
 	}
+	return ColorRed + "désactivé" + ColorReset
+}
 
-	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
-	fmt.Println(stashes)
+// Active commit.gpgSign / tag.gpgSign dans la configuration locale du dépôt
+func (gm *GitManager) toggleSigning() {
+	enable := gm.getGitConfig("commit.gpgSign") != "true"
+	value := strconv.FormatBool(enable)
 
-	fmt.Printf("\n%sIndex du stash (0 pour le plus récent): %s", ColorYellow, ColorReset)
-	indexStr := gm.getUserInput()
+	fmt.Printf("%sSigner aussi les tags annotés? (y/N): %s", ColorYellow, ColorReset)
+	tags := strings.ToLower(gm.getUserInput()) == "y"
 
-	index := 0
-	if indexStr != "" {
-		if i, err := strconv.Atoi(indexStr); err == nil {
-			index = i
-		}
+	gm.runGitCommand("config", "--local", "commit.gpgSign", value)
+	if tags || !enable {
+		gm.runGitCommand("config", "--local", "tag.gpgSign", value)
 	}
 
-	fmt.Printf("%sNom de la nouvelle branche: %s", ColorYellow, ColorReset)
-	branchName := gm.getUserInput()
+	if enable {
+		fmt.Printf("%s✅ Signature activée pour ce dépôt%s\n", ColorGreen, ColorReset)
+		fmt.Printf("%s💡 Utilisez l'option 5 pour vérifier que la clé fonctionne%s\n", ColorCyan, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Signature désactivée pour ce dépôt%s\n", ColorGreen, ColorReset)
+	}
+	gm.pause()
+}
 
-	if branchName == "" {
-		fmt.Printf("%s❌ Nom de branche requis!%s\n", ColorRed, ColorReset)
+func (gm *GitManager) chooseSigningFormat() {
+	fmt.Println("1. GPG (openpgp)")
+	fmt.Println("2. SSH")
+	fmt.Println("3. X.509 (gpgsm)")
+	fmt.Printf("\n%sFormat: %s", ColorYellow, ColorReset)
+
+	formats := map[string]string{"1": "openpgp", "2": "ssh", "3": "x509"}
+	format, ok := formats[gm.getUserInput()]
+	if !ok {
+		fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
 		gm.pause()
 		return
 	}
 
-	stashRef := fmt.Sprintf("stash@{%d}", index)
-	output, err := gm.runGitCommand("stash", "branch", branchName, stashRef)
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Branche '%s' créée depuis le stash %s!%s\n", ColorGreen, branchName, stashRef, ColorReset)
+	gm.runGitCommand("config", "--local", "gpg.format", format)
+	fmt.Printf("%s✅ Format de signature: %s%s\n", ColorGreen, format, ColorReset)
+	if format == "ssh" {
+		fmt.Printf("%s💡 La clé doit être le chemin d'une clé publique (ex: ~/.ssh/id_ed25519.pub)%s\n", ColorCyan, ColorReset)
 	}
 	gm.pause()
 }
 
-// Statistics
-func (gm *GitManager) showGeneralStats() {
-	fmt.Printf("%s%s📊 STATISTIQUES GÉNÉRALES%s\n", ColorBold, ColorBlue, ColorReset)
-	fmt.Println(strings.Repeat("═", 40))
+func (gm *GitManager) chooseSigningKey() {
+	if gm.getGitConfig("gpg.format") == "ssh" {
+		home, _ := os.UserHomeDir()
+		keys, _ := filepath.Glob(filepath.Join(home, ".ssh", "*.pub"))
+		if len(keys) > 0 {
+			fmt.Printf("%s🔑 Clés SSH publiques trouvées:%s\n", ColorBlue, ColorReset)
+			for i, key := range keys {
+				fmt.Printf("  %d. %s\n", i+1, key)
+			}
+		}
+		fmt.Printf("%sNuméro ou chemin de la clé publique: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+		if i, err := strconv.Atoi(choice); err == nil && i >= 1 && i <= len(keys) {
+			choice = keys[i-1]
+		}
+		if choice != "" {
+			gm.runGitCommand("config", "--local", "user.signingkey", choice)
+			fmt.Printf("%s✅ Clé de signature: %s%s\n", ColorGreen, choice, ColorReset)
+		}
+		gm.pause()
+		return
+	}
 
-	totalCommits, _ := gm.runGitCommand("rev-list", "--count", "HEAD")
-	fmt.Printf("%s📦 Total commits: %s%s%s\n", ColorGreen, ColorWhite, totalCommits, ColorReset)
+	if output, err := exec.Command("gpg", "--list-secret-keys", "--keyid-format=long").CombinedOutput(); err == nil {
+		fmt.Printf("%s🔑 Clés GPG secrètes:%s\n%s\n", ColorBlue, ColorReset, strings.TrimSpace(string(output)))
+	} else {
+		fmt.Printf("%s⚠️  Impossible de lister les clés GPG (gpg est-il installé?)%s\n", ColorYellow, ColorReset)
+	}
+	fmt.Printf("%sIdentifiant de la clé (vide pour utiliser l'email du commiteur): %s", ColorYellow, ColorReset)
+	key := gm.getUserInput()
+	if key == "" {
+		gm.runGitCommand("config", "--local", "--unset", "user.signingkey")
+		fmt.Printf("%s✅ Clé par défaut utilisée%s\n", ColorGreen, ColorReset)
+	} else {
+		gm.runGitCommand("config", "--local", "user.signingkey", key)
+		fmt.Printf("%s✅ Clé de signature: %s%s\n", ColorGreen, key, ColorReset)
+	}
+	gm.pause()
+}
 
-	branches, _ := gm.runGitCommand("branch", "-r")
-	branchCount := 0
-	if branches != "" {
-		branchCount = len(strings.Split(strings.TrimSpace(branches), "\n"))
+// Les signatures SSH ne sont vérifiables qu'avec un fichier allowedSigners (email + clé publique)
+func (gm *GitManager) configureAllowedSigners() {
+	current := gm.getGitConfig("gpg.ssh.allowedSignersFile")
+	if current == "" {
+		home, _ := os.UserHomeDir()
+		current = filepath.Join(home, ".ssh", "allowed_signers")
 	}
-	localBranches, _ := gm.runGitCommand("branch")
-	localCount := 0
-	if localBranches != "" {
-		localCount = len(strings.Split(strings.TrimSpace(localBranches), "\n"))
+	fmt.Printf("%sChemin du fichier (défaut: %s): %s", ColorYellow, current, ColorReset)
+	path := gm.getUserInput()
+	if path == "" {
+		path = current
 	}
+	gm.runGitCommand("config", "--local", "gpg.ssh.allowedSignersFile", path)
+	fmt.Printf("%s✅ Fichier des signataires: %s%s\n", ColorGreen, path, ColorReset)
 
-	fmt.Printf("%s🌿 Branches locales: %s%d%s\n", ColorGreen, ColorWhite, localCount, ColorReset)
-	fmt.Printf("%s🌐 Branches remote: %s%d%s\n", ColorGreen, ColorWhite, branchCount, ColorReset)
+	key := gm.getGitConfig("user.signingkey")
+	email := gm.getGitConfig("user.email")
+	if key == "" || email == "" {
+		gm.pause()
+		return
+	}
 
-	tags, _ := gm.runGitCommand("tag")
-	tagCount := 0
-	if tags != "" {
-		tagCount = len(strings.Split(strings.TrimSpace(tags), "\n"))
+	fmt.Printf("%sAjouter votre clé (%s) pour %s? (y/N): %s", ColorYellow, key, email, ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" {
+		gm.pause()
+		return
 	}
-	fmt.Printf("%s🏷️  Tags: %s%d%s\n", ColorGreen, ColorWhite, tagCount, ColorReset)
 
-	firstCommit, _ := gm.runGitCommand("log", "--reverse", "--pretty=format:%h - %s (%an, %ad)", "--date=short", "-1")
-	lastCommit, _ := gm.runGitCommand("log", "--pretty=format:%h - %s (%an, %ad)", "--date=short", "-1")
+	keyPath := key
+	if strings.HasPrefix(keyPath, "~/") {
+		home, _ := os.UserHomeDir()
+		keyPath = filepath.Join(home, keyPath[2:])
+	}
+	publicKey := key
+	if content, err := os.ReadFile(keyPath); err == nil {
+		publicKey = strings.TrimSpace(string(content))
+	}
 
-	fmt.Printf("\n%s📅 Premier commit:%s\n%s\n", ColorYellow, ColorReset, firstCommit)
-	fmt.Printf("%s📅 Dernier commit:%s\n%s\n", ColorYellow, ColorReset, lastCommit)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
+	defer file.Close()
+	fmt.Fprintf(file, "%s %s\n", email, publicKey)
+	fmt.Printf("%s✅ Clé ajoutée aux signataires autorisés%s\n", ColorGreen, ColorReset)
+	gm.pause()
+}
 
-	repoSize, _ := gm.runGitCommand("count-objects", "-vH")
-	fmt.Printf("\n%s💾 Taille du dépôt:%s\n", ColorCyan, ColorReset)
-	fmt.Println(repoSize)
+// Signe un commit temporaire (non référencé) puis le vérifie
+func (gm *GitManager) testSigning() {
+	fmt.Printf("%s🔍 Test de signature...%s\n", ColorBlue, ColorReset)
+	hash, err := gm.runGitCommand("commit-tree", "-S", "-m", "gitman: test de signature", "HEAD^{tree}")
+	if err != nil {
+		fmt.Printf("%s❌ La signature a échoué:%s\n%s\n", ColorRed, ColorReset, hash)
+		fmt.Printf("%s💡 Vérifiez le format, la clé et que l'agent GPG/SSH est disponible%s\n", ColorCyan, ColorReset)
+		gm.pause()
+		return
+	}
 
+	status, _ := gm.runGitCommand("log", "-1", "--format=%G?%x1f%GS", hash)
+	code, signer, _ := strings.Cut(status, "\x1f")
+	fmt.Printf("%s✅ Signature créée%s\n", ColorGreen, ColorReset)
+	fmt.Printf("Vérification: %s %s", signatureBadge(code), signatureLabel(code))
+	if signer != "" {
+		fmt.Printf(" (%s)", signer)
+	}
+	fmt.Println()
 	gm.pause()
 }
 
-func (gm *GitManager) showContributorStats() {
-	fmt.Printf("%s%s👥 STATISTIQUES DES CONTRIBUTEURS%s\n", ColorBold, ColorBlue, ColorReset)
-	fmt.Println(strings.Repeat("═", 45))
+// Rapport de vérification: signale les commits non signés, non fiables ou invalides d'une plage
+func (gm *GitManager) verifySignatures() {
+	defaultRange := "HEAD~20..HEAD"
+	if upstream := gm.getUpstream(gm.getCurrentBranch()); upstream != "" {
+		defaultRange = upstream + "..HEAD"
+	} else if tag, err := gm.runGitCommand("describe", "--tags", "--abbrev=0"); err == nil && tag != "" {
+		defaultRange = tag + "..HEAD"
+	}
 
-	contributors, _ := gm.runGitCommand("shortlog", "-sn", "--all")
-	fmt.Printf("%s📈 Commits par contributeur:%s\n", ColorGreen, ColorReset)
-	fmt.Println(contributors)
+	fmt.Printf("%sPlage à vérifier (défaut: %s): %s", ColorYellow, defaultRange, ColorReset)
+	revRange := gm.getUserInput()
+	if revRange == "" {
+		revRange = defaultRange
+	}
 
-	fmt.Printf("\n%s📅 Activité par mois (12 derniers mois):%s\n", ColorYellow, ColorReset)
-	activity, _ := gm.runGitCommand("log", "--pretty=format:%ad", "--date=format:%Y-%m", "--since=12.months.ago")
-	if activity != "" {
-		months := strings.Split(activity, "\n")
-		monthCount := make(map[string]int)
-		for _, month := range months {
-			monthCount[month]++
+	output, err := gm.runGitCommand("log", "--format=%h%x1f%G?%x1f%GS%x1f%GK%x1f%an%x1f%s", revRange)
+	if err != nil && strings.HasPrefix(revRange, "HEAD~20") {
+		// Historique plus court que 20 commits
+		output, err = gm.runGitCommand("log", "--format=%h%x1f%G?%x1f%GS%x1f%GK%x1f%an%x1f%s", "HEAD")
+	}
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
+	if output == "" {
+		fmt.Printf("%s✅ Aucun commit dans cette plage%s\n", ColorGreen, ColorReset)
+		gm.pause()
+		return
+	}
+
+	counts := make(map[string]int)
+	var flagged []string
+	lines := strings.Split(output, "\n")
+
+	fmt.Printf("\n%s%s🔏 RAPPORT DE SIGNATURES (%s)%s\n", ColorBold, ColorPurple, revRange, ColorReset)
+	fmt.Println(strings.Repeat("═", 50))
+	for _, line := range lines {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) < 6 {
+			continue
 		}
+		hash, code, signer, key, author, subject := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]
+		counts[code]++
+
+		detail := ""
+		if signer != "" {
+			detail = fmt.Sprintf(" %s[%s]%s", ColorCyan, signer, ColorReset)
+		} else if key != "" {
+			detail = fmt.Sprintf(" %s[clé %s]%s", ColorCyan, key, ColorReset)
+		}
+		fmt.Printf("%s %s%s%s %s %s<%s>%s%s\n", signatureBadge(code), ColorYellow, hash, ColorReset, subject, ColorBlue, author, ColorReset, detail)
 
-		var sortedMonths []string
-		for month := range monthCount {
-			sortedMonths = append(sortedMonths, month)
+		if code != "G" {
+			flagged = append(flagged, fmt.Sprintf("%s %s — %s", hash, subject, signatureLabel(code)))
 		}
-		sort.Strings(sortedMonths)
+	}
 
-		for _, month := range sortedMonths {
-			fmt.Printf("%s: %d commits\n", month, monthCount[month])
+	fmt.Printf("\n%s📊 Résumé:%s %d commit(s)\n", ColorCyan, ColorReset, len(lines))
+	for _, code := range []string{"G", "U", "X", "Y", "R", "B", "E", "N"} {
+		if counts[code] > 0 {
+			fmt.Printf("   %s %s: %d\n", signatureBadge(code), signatureLabel(code), counts[code])
 		}
 	}
 
+	if len(flagged) == 0 {
+		fmt.Printf("\n%s✅ Tous les commits ont une signature valide et de confiance%s\n", ColorGreen, ColorReset)
+	} else {
+		fmt.Printf("\n%s⚠️  %d commit(s) à examiner:%s\n", ColorYellow, len(flagged), ColorReset)
+		for _, item := range flagged {
+			fmt.Printf("   • %s\n", item)
+		}
+		if gm.getGitConfig("gpg.format") == "ssh" && gm.getGitConfig("gpg.ssh.allowedSignersFile") == "" {
+			fmt.Printf("%s💡 Configurez le fichier des signataires autorisés pour vérifier les signatures SSH%s\n", ColorCyan, ColorReset)
+		}
+	}
 	gm.pause()
 }
 
-func (gm *GitManager) showDetailedHistory() {
-	fmt.Printf("%sNombre de commits à afficher (défaut: 20): %s", ColorYellow, ColorReset)
-	countStr := gm.getUserInput()
-
-	count := 20
-	if countStr != "" {
-		if c, err := strconv.Atoi(countStr); err == nil {
-			count = c
-		}
-	}
+// Rebase
+// Ligne du todo-list de rebase interactif
+type rebaseTodoItem struct {
+	action  string
+	hash    string
+	subject string
+}
 
-	fmt.Println("\n1. Format court")
-	fmt.Println("2. Format détaillé")
-	fmt.Println("3. Format personnalisé")
+var rebaseActions = map[string]string{
+	"p": "pick", "r": "reword", "e": "edit", "s": "squash", "f": "fixup", "d": "drop",
+}
 
-	fmt.Printf("\n%sChoisissez un format: %s", ColorYellow, ColorReset)
-	choice := gm.getUserInput()
+// Exécute une commande Git attachée au terminal (éditeur, invites) avec un environnement supplémentaire
+func (gm *GitManager) runGitCommandInteractive(env []string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = gm.currentPath
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
 
-	var output string
-	var err error
+// Entoure une valeur d'apostrophes pour le shell utilisé par git pour lancer les éditeurs
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
 
-	switch choice {
-	case "1":
-		output, err = gm.runGitCommand("log", "--oneline", "--graph", "--decorate", fmt.Sprintf("-%d", count))
-	case "2":
-		output, err = gm.runGitCommand("log", "--graph", "--pretty=format:%C(yellow)%h%C(reset) - %C(green)(%ar)%C(reset) %s %C(bold blue)<%an>%C(reset)", fmt.Sprintf("-%d", count))
-	case "3":
-		fmt.Printf("%sFormat personnalisé (ex: %%h - %%s (%%an)): %s", ColorYellow, ColorReset)
-		format := gm.getUserInput()
-		if format != "" {
-			output, err = gm.runGitCommand("log", "--pretty=format:"+format, fmt.Sprintf("-%d", count))
-		}
-	default:
-		output, err = gm.runGitCommand("log", "--oneline", fmt.Sprintf("-%d", count))
+// Construit la variable GIT_SEQUENCE_EDITOR qui rappelle gitman avec le todo préparé
+func sequenceEditorEnv(todoFile string) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
 	}
+	return "GIT_SEQUENCE_EDITOR=" + shellQuote(exe) + " --sequence-editor " + shellQuote(todoFile), nil
+}
 
+// Mode éditeur de séquence: git appelle gitman avec le fichier todo à remplacer par celui préparé
+func runSequenceEditor(preparedFile, todoFile string) error {
+	content, err := os.ReadFile(preparedFile)
 	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s📈 Historique:%s\n", ColorBlue, ColorReset)
-		fmt.Println(output)
+		return err
 	}
+	return os.WriteFile(todoFile, content, 0644)
+}
 
-	gm.pause()
+// Retourne l'opération Git en cours (rebase, merge, cherry-pick, revert, squash) ou ""
+func (gm *GitManager) getOperationInProgress() string {
+	checks := []struct{ path, operation string }{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+		{"MERGE_HEAD", "merge"},
+		// merge --squash ne crée pas de MERGE_HEAD: seul SQUASH_MSG indique le commit restant à faire
+		{"SQUASH_MSG", "squash"},
+	}
+	for _, check := range checks {
+		if _, err := os.Stat(gm.gitPath(check.path)); err == nil {
+			return check.operation
+		}
+	}
+	return ""
 }
 
-func (gm *GitManager) showBranchGraph() {
-	fmt.Printf("%s%s🌳 GRAPHIQUE DES BRANCHES%s\n", ColorBold, ColorBlue, ColorReset)
-	fmt.Println(strings.Repeat("═", 35))
+// Continuer / passer / annuler l'opération en cours (rebase, cherry-pick, revert, merge, squash)
+func (gm *GitManager) handleOperationInProgress() {
+	for {
+		operation := gm.getOperationInProgress()
+		if operation == "" {
+			fmt.Printf("%s✅ Aucune opération en cours.%s\n", ColorGreen, ColorReset)
+			gm.pause()
+			return
+		}
 
-	// Vérifier d'abord s'il y a plusieurs branches
-	localBranches, _ := gm.runGitCommand("branch")
+		gm.clearScreen()
+		fmt.Printf("%s%s⏸️  %s EN COURS%s\n", ColorBold, ColorYellow, strings.ToUpper(operation), ColorReset)
+		fmt.Println(strings.Repeat("═", 30))
 
-	branchCount := len(strings.Split(strings.TrimSpace(localBranches), "\n"))
-	if localBranches == "" {
-		branchCount = 0
+		if operation == "rebase" {
+			if done, err := os.ReadFile(gm.gitPath("rebase-merge/done")); err == nil {
+				lines := strings.Split(strings.TrimSpace(string(done)), "\n")
+				fmt.Printf("%sDernière étape:%s %s\n", ColorBlue, ColorReset, lines[len(lines)-1])
+			}
+		}
+
+		conflicts, _ := gm.runGitCommand("diff", "--name-only", "--diff-filter=U")
+		if conflicts != "" {
+			fmt.Printf("%s⚔️  Fichiers en conflit:%s\n", ColorRed, ColorReset)
+			fmt.Println(conflicts)
+			fmt.Printf("%s💡 Résolvez les conflits puis ajoutez les fichiers (F) avant de continuer.%s\n", ColorYellow, ColorReset)
+		} else {
+			status, _ := gm.runGitCommand("status", "--short")
+			if status != "" {
+				fmt.Printf("%sÉtat:%s\n%s\n", ColorBlue, ColorReset, status)
+			}
+		}
+
+		if operation == "squash" {
+			fmt.Println("\n1. Créer le commit de squash")
+		} else {
+			fmt.Println("\n1. Continuer (--continue)")
+		}
+		if operation != "merge" && operation != "squash" {
+			fmt.Println("2. Passer ce commit (--skip)")
+		}
+		fmt.Println("3. Annuler l'opération (--abort)")
+		fmt.Println("4. Ajouter les fichiers résolus (add)")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		var err error
+		switch choice {
+		case "1":
+			if operation == "squash" {
+				if conflicts != "" {
+					fmt.Printf("%s❌ Des conflits restent à résoudre!%s\n", ColorRed, ColorReset)
+					gm.pause()
+					continue
+				}
+				err = gm.runGitCommandInteractive(nil, "commit", "--no-edit")
+				break
+			}
+			err = gm.runGitCommandInteractive(nil, operation, "--continue")
+		case "2":
+			if operation == "merge" || operation == "squash" {
+				continue
+			}
+			err = gm.runGitCommandInteractive(nil, operation, "--skip")
+		case "3":
+			fmt.Printf("%s⚠️  Annuler le %s et revenir à l'état initial? (y/N): %s", ColorRed, operation, ColorReset)
+			if strings.ToLower(gm.getUserInput()) != "y" {
+				continue
+			}
+			if operation == "squash" {
+				// Pas de merge --abort sans MERGE_HEAD: reset --merge restaure les fichiers touchés
+				err = gm.runGitCommandInteractive(nil, "reset", "--merge")
+			} else {
+				err = gm.runGitCommandInteractive(nil, operation, "--abort")
+			}
+		case "4":
+			gm.addFiles()
+			continue
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			continue
+		}
+
+		if err != nil {
+			fmt.Printf("%s❌ L'opération s'est arrêtée: %v%s\n", ColorRed, err, ColorReset)
+		} else if gm.getOperationInProgress() == "" {
+			fmt.Printf("%s✅ Opération terminée!%s\n", ColorGreen, ColorReset)
+			gm.pause()
+			return
+		}
+		gm.pause()
 	}
+}
 
-	fmt.Printf("%s📊 Analyse des branches:%s\n", ColorCyan, ColorReset)
-	fmt.Printf("   %sBranches locales: %s%d%s\n", ColorBlue, ColorYellow, branchCount, ColorReset)
+// Réordonne les commits fixup!/squash! derrière leur cible, comme --autosquash
+func applyAutosquash(items []rebaseTodoItem) []rebaseTodoItem {
+	var result []rebaseTodoItem
+	var pending []rebaseTodoItem
 
-	if branchCount <= 1 {
-		fmt.Printf("%s💡 Vous n'avez qu'une seule branche, le graphique sera linéaire.%s\n", ColorYellow, ColorReset)
-		fmt.Printf("%s   Créez des branches pour voir un vrai graphique de développement !%s\n\n", ColorYellow, ColorReset)
+	for _, item := range items {
+		if strings.HasPrefix(item.subject, "fixup! ") || strings.HasPrefix(item.subject, "squash! ") {
+			pending = append(pending, item)
+		} else {
+			result = append(result, item)
+		}
 	}
 
-	fmt.Printf("%sNombre de commits à afficher (défaut: 30): %s", ColorYellow, ColorReset)
-	countStr := gm.getUserInput()
+	for _, item := range pending {
+		action, target := "fixup", strings.TrimPrefix(item.subject, "fixup! ")
+		if strings.HasPrefix(item.subject, "squash! ") {
+			action, target = "squash", strings.TrimPrefix(item.subject, "squash! ")
+		}
 
-	count := 30
-	if countStr != "" {
-		if c, err := strconv.Atoi(countStr); err == nil {
-			count = c
+		// Insérer après la cible et ses fixups déjà placés
+		position := -1
+		for i, candidate := range result {
+			isHash := len(target) >= 4 && (strings.HasPrefix(candidate.hash, target) || strings.HasPrefix(target, candidate.hash))
+			if candidate.subject == target || isHash {
+				position = i
+				break
+			}
+		}
+		item.action = action
+		if position < 0 {
+			result = append(result, item)
+			continue
+		}
+		for position+1 < len(result) && (result[position+1].action == "fixup" || result[position+1].action == "squash") {
+			position++
 		}
+		result = append(result[:position+1], append([]rebaseTodoItem{item}, result[position+1:]...)...)
 	}
+	return result
+}
 
-	fmt.Printf("\n%sChoisissez le style d'affichage:%s\n", ColorCyan, ColorReset)
-	fmt.Println("1. Graphique simple (par défaut)")
-	fmt.Println("2. Graphique détaillé avec couleurs")
-	fmt.Println("3. Vue compacte (oneline)")
-	fmt.Println("4. Vue avec statistiques des fichiers")
-	fmt.Println("5. Vue par auteur")
+func printRebaseTodo(items []rebaseTodoItem) {
+	for i, item := range items {
+		color := ColorWhite
+		switch item.action {
+		case "reword", "edit":
+			color = ColorYellow
+		case "squash", "fixup":
+			color = ColorCyan
+		case "drop":
+			color = ColorRed
+		}
+		fmt.Printf("%3d. %s%-7s%s %s%s%s %s\n", i+1, color, item.action, ColorReset, ColorYellow, item.hash, ColorReset, item.subject)
+	}
+}
 
-	fmt.Printf("\n%sStyle (défaut 2): %s", ColorYellow, ColorReset)
-	style := gm.getUserInput()
+// Affiche l'historique tel qu'il sera après le rebase
+func printRebasePreview(items []rebaseTodoItem) {
+	fmt.Printf("\n%s🔮 Résultat prévu (du plus ancien au plus récent):%s\n", ColorBlue, ColorReset)
+	count := 0
+	for _, item := range items {
+		switch item.action {
+		case "drop":
+			continue
+		case "squash", "fixup":
+			fmt.Printf("      %s└─ fusionné: %s%s\n", ColorCyan, item.subject, ColorReset)
+		default:
+			count++
+			note := ""
+			if item.action == "reword" {
+				note = " (message à modifier)"
+			} else if item.action == "edit" {
+				note = " (arrêt pour modification)"
+			}
+			fmt.Printf("   %s●%s %s%s%s%s\n", ColorGreen, ColorReset, item.subject, ColorYellow, note, ColorReset)
+		}
+	}
+	fmt.Printf("%s%d commit(s) après le rebase (avant: %d)%s\n", ColorBlue, count, len(items), ColorReset)
+}
 
-	var output string
-	var err error
+func (gm *GitManager) interactiveRebase() {
+	if operation := gm.getOperationInProgress(); operation != "" {
+		fmt.Printf("%s⚠️  Un %s est déjà en cours.%s\n", ColorYellow, operation, ColorReset)
+		gm.handleOperationInProgress()
+		return
+	}
 
-	switch style {
-	case "1":
-		output, err = gm.runGitCommand("log", "--graph", "--pretty=format:%h - %s (%ar) <%an>", "--all", fmt.Sprintf("-%d", count))
-	case "3":
-		output, err = gm.runGitCommand("log", "--graph", "--oneline", "--all", fmt.Sprintf("-%d", count))
-	case "4":
-		output, err = gm.runGitCommand("log", "--graph", "--stat", "--pretty=format:%C(yellow)%h%C(reset) - %s %C(green)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)", fmt.Sprintf("-%d", count))
-	case "5":
-		// Vue par auteur avec couleurs
-		output, err = gm.runGitCommand("log", "--graph", "--pretty=format:%C(red)%h%C(reset) %C(green)%s%C(reset) %C(yellow)(%ar)%C(reset) %C(bold blue)by %an%C(reset)", "--all", fmt.Sprintf("-%d", count))
-	default: // Style 2 - détaillé avec couleurs (par défaut)
-		output, err = gm.runGitCommand("log", "--graph", "--pretty=format:%C(auto)%h%C(reset) %C(bold)-%C(reset) %C(white)%s%C(reset) %C(green)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)", "--all", fmt.Sprintf("-%d", count))
+	defaultBase := gm.getUpstream(gm.getCurrentBranch())
+	if defaultBase == "" {
+		defaultBase = "HEAD~10"
+	}
+	fmt.Printf("%s📈 Derniers commits:%s\n", ColorBlue, ColorReset)
+	recent, _ := gm.runGitCommand("log", "--oneline", "--decorate", "-15")
+	fmt.Println(recent)
+
+	fmt.Printf("\n%sBase du rebase (ex: HEAD~5, main; défaut '%s'): %s", ColorYellow, defaultBase, ColorReset)
+	base := gm.getUserInput()
+	if base == "" {
+		base = defaultBase
 	}
 
+	gm.runInteractiveRebase(base, nil, false)
+}
+
+// Éditeur de todo-list; initial permet de pré-remplir les actions (hash court -> action)
+func (gm *GitManager) runInteractiveRebase(base string, initial map[string]string, autosquash bool) bool {
+	output, err := gm.runGitCommand("log", "--reverse", "--no-merges", "--format=%h|%s", base+"..HEAD")
 	if err != nil {
 		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("\n%s🌿 Historique des branches:%s\n", ColorGreen, ColorReset)
-		fmt.Println(strings.Repeat("─", 50))
-		fmt.Println(output)
+		gm.pause()
+		return false
+	}
+	if output == "" {
+		fmt.Printf("%s❌ Aucun commit à rebaser depuis '%s'.%s\n", ColorRed, base, ColorReset)
+		gm.pause()
+		return false
+	}
 
-		// Ajouter des statistiques utiles
-		fmt.Printf("\n%s📈 Statistiques rapides:%s\n", ColorBlue, ColorReset)
+	var items []rebaseTodoItem
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "|", 2)
+		if len(parts) == 2 {
+			action := "pick"
+			if a, ok := initial[parts[0]]; ok {
+				action = a
+			}
+			items = append(items, rebaseTodoItem{action: action, hash: parts[0], subject: parts[1]})
+		}
+	}
 
-		// Dernière activité
-		lastCommit, _ := gm.runGitCommand("log", "-1", "--pretty=format:%ar")
-		fmt.Printf("   %sDernière activité: %s%s%s\n", ColorCyan, ColorWhite, lastCommit, ColorReset)
+	autostash := gm.getGitStatus() != ""
+	// Todo avant autosquash, restauré quand l'option est désactivée
+	beforeAutosquash := append([]rebaseTodoItem(nil), items...)
+	if autosquash {
+		items = applyAutosquash(items)
+	}
 
-		// Nombre total de commits
-		totalCommits, _ := gm.runGitCommand("rev-list", "--count", "HEAD")
-		fmt.Printf("   %sTotal commits: %s%s%s\n", ColorCyan, ColorWhite, totalCommits, ColorReset)
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s✏️  REBASE INTERACTIF sur %s%s\n", ColorBold, ColorPurple, base, ColorReset)
+		fmt.Println(strings.Repeat("═", 50))
+		printRebaseTodo(items)
+
+		fmt.Printf("\n%sOptions:%s autosquash=%v  autostash=%v\n", ColorBlue, ColorReset, autosquash, autostash)
+		fmt.Println("Commandes: p|r|e|s|f|d <n>  (pick/reword/edit/squash/fixup/drop)")
+		fmt.Println("           m <n> <pos> (déplacer)   v (aperçu)   a (autosquash)   t (autostash)")
+		fmt.Println("           g (lancer le rebase)     q (annuler)")
+
+		fmt.Printf("\n%sCommande: %s", ColorYellow, ColorReset)
+		fields := strings.Fields(gm.getUserInput())
+		if len(fields) == 0 {
+			continue
+		}
 
-		// Branches remote si elles existent
-		remoteBranches, _ := gm.runGitCommand("branch", "-r")
-		if remoteBranches != "" {
-			remoteCount := len(strings.Split(strings.TrimSpace(remoteBranches), "\n"))
-			fmt.Printf("   %sBranches remote: %s%d%s\n", ColorCyan, ColorWhite, remoteCount, ColorReset)
+		switch command := strings.ToLower(fields[0]); command {
+		case "q":
+			return false
+		case "v":
+			printRebasePreview(items)
+			gm.pause()
+		case "a":
+			autosquash = !autosquash
+			if autosquash {
+				beforeAutosquash = append([]rebaseTodoItem(nil), items...)
+				items = applyAutosquash(items)
+			} else {
+				items = append([]rebaseTodoItem(nil), beforeAutosquash...)
+			}
+		case "t":
+			autostash = !autostash
+		case "m":
+			if len(fields) < 3 {
+				continue
+			}
+			from, err1 := strconv.Atoi(fields[1])
+			to, err2 := strconv.Atoi(fields[2])
+			if err1 != nil || err2 != nil || from < 1 || from > len(items) || to < 1 || to > len(items) {
+				fmt.Printf("%s❌ Positions invalides!%s\n", ColorRed, ColorReset)
+				gm.pause()
+				continue
+			}
+			item := items[from-1]
+			items = append(items[:from-1], items[from:]...)
+			items = append(items[:to-1], append([]rebaseTodoItem{item}, items[to-1:]...)...)
+		case "g":
+			if items[0].action == "squash" || items[0].action == "fixup" {
+				fmt.Printf("%s❌ Le premier commit ne peut pas être squash/fixup.%s\n", ColorRed, ColorReset)
+				gm.pause()
+				continue
+			}
+			printRebasePreview(items)
+			fmt.Printf("\n%sLancer le rebase? (y/N): %s", ColorYellow, ColorReset)
+			if strings.ToLower(gm.getUserInput()) != "y" {
+				continue
+			}
+			return gm.executeRebaseTodo(base, items, autosquash, autostash)
+		default:
+			action, ok := rebaseActions[command]
+			if !ok || len(fields) < 2 {
+				fmt.Printf("%s❌ Commande invalide!%s\n", ColorRed, ColorReset)
+				gm.pause()
+				continue
+			}
+			for _, field := range fields[1:] {
+				if n, err := strconv.Atoi(field); err == nil && n >= 1 && n <= len(items) {
+					items[n-1].action = action
+				}
+			}
 		}
 	}
+}
+
+// Écrit le todo préparé et lance git rebase -i avec gitman comme éditeur de séquence
+func (gm *GitManager) executeRebaseTodo(base string, items []rebaseTodoItem, autosquash, autostash bool) bool {
+	// --no-autosquash explicite: rebase.autoSquash ne doit pas réappliquer ce qui a été désactivé
+	args := []string{"--no-autosquash"}
+	if autosquash {
+		args = []string{"--autosquash"}
+	}
+	if autostash {
+		args = append(args, "--autostash")
+	}
+
+	err := gm.startRebaseTodo(base, items, args, nil)
+	if gm.getOperationInProgress() == "rebase" {
+		fmt.Printf("\n%s⏸️  Rebase interrompu (edit ou conflit).%s\n", ColorYellow, ColorReset)
+		gm.pause()
+		gm.handleOperationInProgress()
+		return gm.getOperationInProgress() == ""
+	}
+	if err != nil {
+		fmt.Printf("%s❌ Le rebase a échoué: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return false
+	}
 
+	fmt.Printf("%s✅ Rebase terminé!%s\n", ColorGreen, ColorReset)
+	result, _ := gm.runGitCommand("log", "--oneline", base+"..HEAD")
+	fmt.Println(result)
 	gm.pause()
+	return true
 }
 
-// Nouvelle fonction pour afficher un arbre complet de tous les commits et branches
-func (gm *GitManager) showCompleteTree() {
-	fmt.Printf("%s%s🌳 VUE ARBRE COMPLÈTE - TOUS COMMITS & BRANCHES%s\n", ColorBold, ColorGreen, ColorReset)
-	fmt.Println(strings.Repeat("═", 55))
-
-	currentBranch := gm.getCurrentBranch()
-	fmt.Printf("%s📍 Branche actuelle: %s%s%s\n\n", ColorBlue, ColorCyan, currentBranch, ColorReset)
+// Fixup / Autosquash
+// Base de la branche courante: merge-base avec la branche principale, sinon avec l'upstream.
+// Retourne "" si aucune base n'est trouvée (ex: branche principale sans upstream).
+func (gm *GitManager) branchMergeBase() (base, label string) {
+	current := gm.getCurrentBranch()
+	main := gm.loadFlowConfig().mainBranch
 
-	fmt.Printf("%sNombre de commits à afficher (défaut: 50): %s", ColorYellow, ColorReset)
-	countStr := gm.getUserInput()
+	candidates := []string{}
+	if current != main {
+		candidates = append(candidates, main, "origin/"+main)
+	}
+	if upstream := gm.getUpstream(current); upstream != "" {
+		candidates = append(candidates, upstream)
+	}
 
-	count := 50
-	if countStr != "" {
-		if c, err := strconv.Atoi(countStr); err == nil {
-			count = c
+	for _, candidate := range candidates {
+		if mergeBase, err := gm.runGitCommand("merge-base", "HEAD", candidate); err == nil && mergeBase != "" {
+			return mergeBase, candidate
 		}
 	}
+	return "", ""
+}
 
-	fmt.Printf("\n%sStyle d'arbre:%s\n", ColorCyan, ColorReset)
-	fmt.Println("1. Arbre complet avec toutes les branches (recommandé)")
-	fmt.Println("2. Arbre avec couleurs et décoration avancée")
-	fmt.Println("3. Arbre compact avec hash courts")
-	fmt.Println("4. Arbre avec informations de merge")
-	fmt.Println("5. Arbre ASCII artistique")
-
-	fmt.Printf("\n%sStyle (défaut 1): %s", ColorYellow, ColorReset)
-	style := gm.getUserInput()
+// Ensemble des commits (hash court) de HEAD absents de tous les remotes
+func (gm *GitManager) unpushedCommits() map[string]bool {
+	unpushed := make(map[string]bool)
+	output, _ := gm.runGitCommand("rev-list", "--abbrev-commit", "HEAD", "--not", "--remotes")
+	for _, hash := range strings.Split(output, "\n") {
+		if hash != "" {
+			unpushed[hash] = true
+		}
+	}
+	return unpushed
+}
 
-	var output string
-	var err error
+// Crée un commit fixup!/squash! ciblant un commit de la branche puis propose l'autosquash
+func (gm *GitManager) fixupCommit() {
+	if operation := gm.getOperationInProgress(); operation != "" {
+		fmt.Printf("%s⚠️  Un %s est déjà en cours.%s\n", ColorYellow, operation, ColorReset)
+		gm.handleOperationInProgress()
+		return
+	}
 
-	switch style {
-	case "2":
-		// Arbre avec couleurs et décoration avancée
-		output, err = gm.runGitCommand("log",
-			"--graph",
-			"--all",
-			"--decorate",
-			"--pretty=format:%C(auto)%h%C(reset) %C(bold yellow)│%C(reset) %C(white)%s%C(reset) %C(dim)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)%C(auto)%d%C(reset)",
-			fmt.Sprintf("-%d", count))
-	case "3":
-		// Arbre compact
-		output, err = gm.runGitCommand("log",
-			"--graph",
-			"--all",
-			"--oneline",
-			"--decorate",
-			fmt.Sprintf("-%d", count))
-	case "4":
-		// Arbre avec informations de merge
-		output, err = gm.runGitCommand("log",
-			"--graph",
-			"--all",
-			"--decorate",
-			"--merges",
-			"--pretty=format:%C(yellow)%h%C(reset) %C(bold)MERGE:%C(reset) %C(white)%s%C(reset) %C(green)(%ar)%C(reset) %C(blue)<%an>%C(reset)%C(auto)%d%C(reset)",
-			fmt.Sprintf("-%d", count))
-	case "5":
-		// Arbre ASCII artistique
-		output, err = gm.runGitCommand("log",
-			"--graph",
-			"--all",
-			"--decorate",
-			"--pretty=format:%C(red)●%C(reset) %C(yellow)%h%C(reset) %C(bold)━%C(reset) %C(white)%s%C(reset)%C(auto)%d%C(reset)%n%C(dim)  ╰─ %ar by %an%C(reset)",
-			fmt.Sprintf("-%d", count))
-	default: // Style 1 - Arbre complet (par défaut)
-		output, err = gm.runGitCommand("log",
-			"--graph",
-			"--all",
-			"--decorate",
-			"--date=relative",
-			"--pretty=format:%C(auto)%h%C(reset) - %C(white)%s%C(reset) %C(green)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)%C(auto)%d%C(reset)",
-			fmt.Sprintf("-%d", count))
+	staged, _ := gm.runGitCommand("diff", "--cached", "--name-only")
+	if staged == "" {
+		fmt.Printf("%sAucun fichier en stage. Voulez-vous ajouter des fichiers? (y/N): %s", ColorYellow, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
+			return
+		}
+		gm.addFiles()
+		if staged, _ = gm.runGitCommand("diff", "--cached", "--name-only"); staged == "" {
+			fmt.Printf("%s❌ Aucun fichier en stage. Annulation.%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
 	}
 
-	if err != nil {
-		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	if !gm.checkStagedChanges() {
 		gm.pause()
 		return
 	}
 
-	// Afficher l'arbre
-	fmt.Printf("\n%s🌿 ARBRE COMPLET:%s\n", ColorGreen, ColorReset)
-	fmt.Println(strings.Repeat("─", 80))
-	fmt.Println(output)
+	base, label := gm.branchMergeBase()
+	var output string
+	if base != "" {
+		output, _ = gm.runGitCommand("log", "--format=%h%x1f%s%x1f%ar", "--no-merges", base+"..HEAD")
+	}
+	if output == "" {
+		// Branche déjà intégrée ou sans base: on propose les derniers commits
+		base = ""
+		output, _ = gm.runGitCommand("log", "--format=%h%x1f%s%x1f%ar", "--no-merges", "-15")
+	}
+	if output == "" {
+		fmt.Printf("%s❌ Aucun commit propre à cette branche%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
 
-	// Ajouter un résumé des branches
-	fmt.Printf("\n%s📊 RÉSUMÉ DES BRANCHES:%s\n", ColorBlue, ColorReset)
-	gm.showBranchSummary()
+	var commits [][]string
+	unpushed := gm.unpushedCommits()
+	if base != "" {
+		fmt.Printf("%s📈 Commits depuis la base avec %s:%s\n", ColorBlue, label, ColorReset)
+	} else {
+		fmt.Printf("%s📈 Derniers commits (aucune base trouvée):%s\n", ColorBlue, ColorReset)
+	}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) < 3 {
+			continue
+		}
+		commits = append(commits, fields)
+		pushed := ""
+		if !unpushed[fields[0]] {
+			pushed = fmt.Sprintf(" %s[pushé]%s", ColorRed, ColorReset)
+		}
+		fmt.Printf("  %2d. %s%s%s %s %s(%s)%s%s\n", len(commits), ColorYellow, fields[0], ColorReset, fields[1], ColorGreen, fields[2], ColorReset, pushed)
+	}
+
+	fmt.Printf("\n%sCommit à corriger (numéro): %s", ColorYellow, ColorReset)
+	index, err := strconv.Atoi(gm.getUserInput())
+	if err != nil || index < 1 || index > len(commits) {
+		fmt.Printf("%s❌ Sélection invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	target := commits[index-1]
+
+	if !unpushed[target[0]] {
+		fmt.Printf("%s⚠️  Le commit %s est déjà pushé: l'autosquash réécrira un historique partagé (push forcé nécessaire).%s\n", ColorRed, target[0], ColorReset)
+		fmt.Printf("%sContinuer? (y/N): %s", ColorYellow, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
+			return
+		}
+	}
+
+	fmt.Println("\n1. fixup (garder le message de la cible)")
+	fmt.Println("2. squash (combiner les messages)")
+	fmt.Printf("\n%sType (défaut 1): %s", ColorYellow, ColorReset)
+	args := []string{"commit", "--fixup=" + target[0]}
+	if gm.getUserInput() == "2" {
+		args = []string{"commit", "--squash=" + target[0], "--no-edit"}
+		fmt.Printf("%sMessage à ajouter (optionnel): %s", ColorYellow, ColorReset)
+		if message := gm.getUserInput(); message != "" {
+			args = []string{"commit", "--squash=" + target[0], "-m", message}
+		}
+	}
+
+	if result, err := gm.runGitCommand(args...); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, result, ColorReset)
+		gm.pause()
+		return
+	}
+	fmt.Printf("%s✅ Commit de correction créé pour %s %s%s\n", ColorGreen, target[0], target[1], ColorReset)
+
+	fmt.Printf("\n%sLancer l'autosquash maintenant? (y/N): %s", ColorYellow, ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" {
+		fmt.Printf("%s💡 Vous pourrez le lancer plus tard via le rebase interactif (option autosquash)%s\n", ColorCyan, ColorReset)
+		gm.pause()
+		return
+	}
+	gm.runAutosquash(base, target[0])
+}
+
+// Rebase --autosquash sans aucun éditeur, jusqu'à la base (ou au parent de la cible)
+func (gm *GitManager) runAutosquash(base, target string) {
+	if !gm.confirmProtectedAction(gm.getCurrentBranch(), "rebase") {
+		gm.pause()
+		return
+	}
+
+	args := []string{"rebase", "-i", "--autosquash"}
+	if gm.getGitStatus() != "" {
+		args = append(args, "--autostash")
+	}
+	if base == "" {
+		if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", target+"^"); err == nil {
+			base = target + "^"
+		}
+	}
+	if base == "" {
+		args = append(args, "--root")
+	} else {
+		args = append(args, base)
+	}
+
+	// GIT_SEQUENCE_EDITOR=true accepte le todo tel que réordonné par --autosquash,
+	// GIT_EDITOR=true garde le message combiné des squash! sans ouvrir d'éditeur
+	err := gm.runGitCommandInteractive([]string{"GIT_SEQUENCE_EDITOR=true", "GIT_EDITOR=true"}, args...)
+	if gm.getOperationInProgress() == "rebase" {
+		fmt.Printf("\n%s⏸️  Autosquash interrompu (conflit).%s\n", ColorYellow, ColorReset)
+		gm.pause()
+		gm.handleOperationInProgress()
+		return
+	}
+	if err != nil {
+		fmt.Printf("%s❌ L'autosquash a échoué: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
 
+	fmt.Printf("%s✅ Autosquash terminé!%s\n", ColorGreen, ColorReset)
+	result, _ := gm.runGitCommand("log", "--oneline", "-15")
+	fmt.Println(result)
 	gm.pause()
 }
 
-// Fonction helper pour afficher un résumé des branches
-func (gm *GitManager) showBranchSummary() {
-	// Branches locales avec leurs derniers commits
-	localBranches, _ := gm.runGitCommand("for-each-ref",
-		"--format=%(refname:short)|%(objectname:short)|%(committerdate:relative)|%(subject)",
-		"refs/heads/")
+// History Rewriting
+// Écrit le todo préparé et lance git rebase -i depuis base ("" pour --root), sans interaction
+func (gm *GitManager) startRebaseTodo(base string, items []rebaseTodoItem, extraArgs, env []string) error {
+	todoFile, err := os.CreateTemp("", "gitman-rebase-todo-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(todoFile.Name())
 
-	if localBranches != "" {
-		fmt.Printf("%s🏠 Branches locales:%s\n", ColorCyan, ColorReset)
-		lines := strings.Split(localBranches, "\n")
-		for _, line := range lines {
-			if line != "" {
-				parts := strings.Split(line, "|")
-				if len(parts) >= 4 {
-					branchName := parts[0]
-					hash := parts[1]
-					date := parts[2]
-					subject := parts[3]
+	for _, item := range items {
+		fmt.Fprintf(todoFile, "%s %s %s\n", item.action, item.hash, item.subject)
+	}
+	todoFile.Close()
 
-					// Limiter la longueur du message
-					if len(subject) > 50 {
-						subject = subject[:47] + "..."
-					}
+	editorEnv, err := sequenceEditorEnv(todoFile.Name())
+	if err != nil {
+		return err
+	}
 
-					currentBranch := gm.getCurrentBranch()
-					if branchName == currentBranch {
-						fmt.Printf("   %s* %-15s%s %s%s%s (%s) - %s\n",
-							ColorGreen, branchName, ColorReset, ColorYellow, hash, ColorReset, date, subject)
-					} else {
-						fmt.Printf("     %-15s %s%s%s (%s) - %s\n",
-							branchName, ColorYellow, hash, ColorReset, date, subject)
-					}
-				}
-			}
-		}
+	args := append([]string{"rebase", "-i"}, extraArgs...)
+	if base == "" {
+		args = append(args, "--root")
+	} else {
+		args = append(args, base)
 	}
+	return gm.runGitCommandInteractive(append([]string{editorEnv}, env...), args...)
+}
 
-	// Branches remote si elles existent
-	remoteBranches, _ := gm.runGitCommand("for-each-ref",
-		"--format=%(refname:short)|%(objectname:short)|%(committerdate:relative)",
-		"refs/remotes/")
+// Annule le rebase en cours et vérifie que la branche est revenue à son état d'origine
+func (gm *GitManager) restoreAfterRebase(original string) {
+	if gm.getOperationInProgress() == "rebase" {
+		gm.runGitCommand("rebase", "--abort")
+	}
+	if head, _ := gm.runGitCommand("rev-parse", "HEAD"); head != original {
+		gm.runGitCommand("reset", "--keep", original)
+	}
+	fmt.Printf("%s↩️  Branche restaurée à son état d'origine (%s)%s\n", ColorYellow, original[:7], ColorReset)
+}
 
-	if remoteBranches != "" {
-		fmt.Printf("\n%s🌐 Branches remote:%s\n", ColorCyan, ColorReset)
-		lines := strings.Split(remoteBranches, "\n")
-		for _, line := range lines {
-			if line != "" {
-				parts := strings.Split(line, "|")
-				if len(parts) >= 3 {
-					branchName := parts[0]
-					hash := parts[1]
-					date := parts[2]
-					fmt.Printf("     %-20s %s%s%s (%s)\n",
-						branchName, ColorYellow, hash, ColorReset, date)
-				}
-			}
-		}
+// Reprend le rebase après un arrêt volontaire; en cas de conflit, propose de résoudre ou de tout restaurer
+func (gm *GitManager) continueRewrite(original string) bool {
+	err := gm.runGitCommandInteractive([]string{"GIT_EDITOR=true"}, "rebase", "--continue")
+	if err == nil && gm.getOperationInProgress() == "" {
+		return true
 	}
 
-	// Statistiques rapides
-	totalCommits, _ := gm.runGitCommand("rev-list", "--count", "--all")
-	fmt.Printf("\n%s📈 Total commits (toutes branches): %s%s%s\n", ColorGreen, ColorWhite, totalCommits, ColorReset)
+	fmt.Printf("\n%s⚔️  Le rebase s'est arrêté (conflit avec un commit suivant).%s\n", ColorRed, ColorReset)
+	fmt.Println("1. Résoudre les conflits maintenant")
+	fmt.Println("2. Tout annuler et restaurer la branche")
+	fmt.Printf("\n%sChoisissez une option (défaut 2): %s", ColorYellow, ColorReset)
+	if gm.getUserInput() == "1" {
+		gm.handleOperationInProgress()
+		return gm.getOperationInProgress() == ""
+	}
+	gm.restoreAfterRebase(original)
+	return false
 }
 
-// Fonction bonus pour visualiser l'arbre des branches de manière plus graphique
-func (gm *GitManager) showBranchTree() {
-	fmt.Printf("%s%s🌲 ARBRE DES BRANCHES%s\n", ColorBold, ColorGreen, ColorReset)
-	fmt.Println(strings.Repeat("═", 25))
+// Modifie un commit non pushé de l'historique: reword, edit, drop ou split
+func (gm *GitManager) rewriteHistoryCommit(ref string) {
+	if operation := gm.getOperationInProgress(); operation != "" {
+		fmt.Printf("%s❌ Un %s est déjà en cours.%s\n", ColorRed, operation, ColorReset)
+		gm.pause()
+		return
+	}
 
-	// Lister toutes les branches avec leurs derniers commits
-	branches, _ := gm.runGitCommand("branch", "-v")
-	fmt.Printf("%s📋 Branches locales:%s\n", ColorBlue, ColorReset)
-	fmt.Println(branches)
+	target, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		fmt.Printf("%s❌ Commit introuvable: %s%s\n", ColorRed, ref, ColorReset)
+		gm.pause()
+		return
+	}
+	if _, err := gm.runGitCommand("merge-base", "--is-ancestor", target, "HEAD"); err != nil {
+		fmt.Printf("%s❌ Ce commit n'appartient pas à la branche courante%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	if remotes, _ := gm.runGitCommand("branch", "-r", "--contains", target); remotes != "" {
+		fmt.Printf("%s❌ Ce commit est déjà pushé (%s).%s\n", ColorRed, strings.Fields(remotes)[0], ColorReset)
+		fmt.Printf("%s💡 Utilisez un revert pour annuler un commit partagé.%s\n", ColorCyan, ColorReset)
+		gm.pause()
+		return
+	}
+	if merges, _ := gm.runGitCommand("rev-list", "--merges", target+"..HEAD"); merges != "" {
+		fmt.Printf("%s❌ Des commits de merge suivent ce commit: réécriture non supportée%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	if !gm.confirmProtectedAction(gm.getCurrentBranch(), "réécriture de l'historique") {
+		gm.pause()
+		return
+	}
 
-	// Afficher les relations entre branches
-	fmt.Printf("\n%s🔗 Relations entre branches:%s\n", ColorYellow, ColorReset)
+	summary, _ := gm.runGitCommand("log", "-1", "--format=%h %s", target)
+	fmt.Printf("\n%s✏️  Commit: %s%s\n", ColorPurple, summary, ColorReset)
+	fmt.Println("1. Modifier le message (reword)")
+	fmt.Println("2. Modifier le contenu (edit)")
+	fmt.Println("3. Supprimer le commit (drop)")
+	fmt.Println("4. Découper en plusieurs commits (split)")
+	fmt.Println("0. Annuler")
+	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
 
-	// Pour chaque branche, montrer depuis quel commit elle diverge
-	localBranches, _ := gm.runGitCommand("branch", "--format=%(refname:short)")
-	if localBranches != "" {
-		branchLines := strings.Split(strings.TrimSpace(localBranches), "\n")
-		currentBranch := gm.getCurrentBranch()
+	actions := map[string]string{"1": "reword", "2": "edit", "3": "drop", "4": "edit"}
+	choice := gm.getUserInput()
+	action, ok := actions[choice]
+	if !ok {
+		return
+	}
 
-		for _, branch := range branchLines {
-			if branch != "" && branch != currentBranch {
-				// Trouver le commit de divergence
-				mergeBase, _ := gm.runGitCommand("merge-base", currentBranch, branch)
-				if mergeBase != "" {
-					shortHash := mergeBase[:7]
-					commitMsg, _ := gm.runGitCommand("log", "-1", "--pretty=format:%s", mergeBase)
-					fmt.Printf("   %s%s%s ──┬── %s%s%s\n", ColorCyan, currentBranch, ColorReset, ColorYellow, branch, ColorReset)
-					fmt.Printf("      │   └─ diverge depuis: %s%s - %s%s\n", ColorGreen, shortHash, commitMsg, ColorReset)
-				}
+	base := ""
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", target+"^"); err == nil {
+		base = target + "^"
+	} else if choice == "4" {
+		fmt.Printf("%s❌ Le commit racine ne peut pas être découpé%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	revRange := "HEAD"
+	if base != "" {
+		revRange = base + "..HEAD"
+	}
+	output, _ := gm.runGitCommand("log", "--reverse", "--format=%H|%s", revRange)
+	var items []rebaseTodoItem
+	for _, line := range strings.Split(output, "\n") {
+		if hash, subject, found := strings.Cut(line, "|"); found {
+			item := rebaseTodoItem{action: "pick", hash: hash, subject: subject}
+			if hash == target {
+				item.action = action
 			}
+			items = append(items, item)
 		}
+	}
 
-		if len(branchLines) == 1 {
-			fmt.Printf("   %s%s%s (branche unique)\n", ColorGreen, currentBranch, ColorReset)
-			fmt.Printf("   └─ %s💡 Créez des branches pour voir les relations !%s\n", ColorYellow, ColorReset)
+	var extraArgs, env []string
+	if gm.getGitStatus() != "" {
+		extraArgs = append(extraArgs, "--autostash")
+	}
+
+	if action == "reword" {
+		current, _ := gm.runGitCommand("log", "-1", "--format=%B", target)
+		fmt.Printf("%sMessage actuel:%s\n%s\n", ColorCyan, ColorReset, current)
+		message, _ := gm.promptMessage("Nouveau message", current, nil)
+		if message == "" {
+			fmt.Printf("%s❌ Message de commit requis!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+		messageFile, err := os.CreateTemp("", "gitman-reword-*")
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+			gm.pause()
+			return
+		}
+		defer os.Remove(messageFile.Name())
+		messageFile.WriteString(message + "\n")
+		messageFile.Close()
+		// git "édite" le message en copiant celui préparé
+		env = append(env, "GIT_EDITOR=cp "+shellQuote(messageFile.Name()))
+	}
+
+	if action == "drop" {
+		fmt.Printf("%s⚠️  Supprimer définitivement '%s'? (y/N): %s", ColorRed, summary, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
+			return
 		}
 	}
 
+	original, _ := gm.runGitCommand("rev-parse", "HEAD")
+	err = gm.startRebaseTodo(base, items, extraArgs, env)
+
+	switch {
+	case action != "edit" && (err != nil || gm.getOperationInProgress() != ""):
+		fmt.Printf("%s❌ La réécriture a échoué (conflit avec un commit suivant).%s\n", ColorRed, ColorReset)
+		gm.restoreAfterRebase(original)
+	case action != "edit":
+		fmt.Printf("%s✅ Historique réécrit!%s\n", ColorGreen, ColorReset)
+		result, _ := gm.runGitCommand("log", "--oneline", "-10")
+		fmt.Println(result)
+	case gm.getOperationInProgress() != "rebase":
+		fmt.Printf("%s❌ Le rebase n'a pas pu s'arrêter sur le commit: %v%s\n", ColorRed, err, ColorReset)
+		gm.restoreAfterRebase(original)
+	case choice == "4":
+		gm.splitStoppedCommit(original, target)
+		return
+	default:
+		gm.editStoppedCommit(original)
+		return
+	}
 	gm.pause()
 }
 
-func (gm *GitManager) showFileStats() {
-	for {
-		gm.clearScreen()
-		fmt.Printf("%s%s📁 STATISTIQUES PAR FICHIER%s\n", ColorBold, ColorBlue, ColorReset)
-		fmt.Println(strings.Repeat("═", 35))
-
-		fmt.Println("1. Fichiers les plus modifiés")
-		fmt.Println("2. Lignes ajoutées/supprimées par fichier")
-		fmt.Println("3. Historique d'un fichier spécifique")
-		fmt.Println("0. Retour")
+// Rebase arrêté sur le commit à modifier: l'utilisateur change les fichiers puis le commit est amendé
+func (gm *GitManager) editStoppedCommit(original string) {
+	fmt.Printf("\n%s⏸️  Rebase arrêté sur le commit à modifier.%s\n", ColorYellow, ColorReset)
+	fmt.Printf("%sModifiez les fichiers dans %s, puis revenez ici.%s\n", ColorCyan, gm.currentPath, ColorReset)
 
+	for {
+		fmt.Println("\n1. Amender avec toutes les modifications des fichiers suivis et continuer")
+		fmt.Println("2. Amender uniquement avec ce qui est en stage et continuer")
+		fmt.Println("3. Ajouter des fichiers (y compris nouveaux)")
+		fmt.Println("4. Ajouter par morceaux (hunks)")
+		fmt.Println("5. Voir les modifications")
+		fmt.Println("6. Laisser le rebase en pause (reprise via l'option 8 du menu commits)")
+		fmt.Println("0. Tout annuler et restaurer la branche")
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
-
-		switch choice {
-		case "1":
-			output, err := gm.runGitCommand("log", "--pretty=format:", "--name-only")
-			if err != nil {
-				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-			} else {
-				files := strings.Split(output, "\n")
-				fileCount := make(map[string]int)
-				for _, file := range files {
-					if file != "" {
-						fileCount[file]++
-					}
-				}
-
-				type fileInfo struct {
-					name  string
-					count int
-				}
-				var sortedFiles []fileInfo
-				for name, count := range fileCount {
-					sortedFiles = append(sortedFiles, fileInfo{name, count})
-				}
-
-				sort.Slice(sortedFiles, func(i, j int) bool {
-					return sortedFiles[i].count > sortedFiles[j].count
-				})
 
-				fmt.Printf("%s📊 Fichiers les plus modifiés:%s\n", ColorGreen, ColorReset)
-				for i, file := range sortedFiles {
-					if i >= 20 {
-						break
-					}
-					fmt.Printf("%s%3d%s modifications - %s\n", ColorYellow, file.count, ColorReset, file.name)
+		switch choice := gm.getUserInput(); choice {
+		case "1", "2":
+			if choice == "1" {
+				// add -u: les fichiers non suivis de l'arbre ne doivent pas entrer dans le commit historique
+				gm.runGitCommand("add", "-u")
+			}
+			if staged, _ := gm.runGitCommand("diff", "--cached", "--name-only"); staged != "" {
+				if output, err := gm.runGitCommand("commit", "--amend", "--no-edit"); err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+					continue
 				}
 			}
+			if untracked, _ := gm.runGitCommand("ls-files", "--others", "--exclude-standard"); untracked != "" {
+				fmt.Printf("%s💡 Fichiers non suivis laissés hors du commit:%s\n%s\n", ColorCyan, ColorReset, untracked)
+			}
+			if gm.continueRewrite(original) {
+				fmt.Printf("%s✅ Commit modifié et historique réécrit!%s\n", ColorGreen, ColorReset)
+			}
 			gm.pause()
-
-		case "2":
-			output, err := gm.runGitCommand("log", "--numstat", "--pretty=format:")
-			if err != nil {
-				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-			} else {
-				lines := strings.Split(output, "\n")
-				fileStats := make(map[string][2]int) // [additions, deletions]
-
-				for _, line := range lines {
-					if line != "" {
-						parts := strings.Fields(line)
-						if len(parts) == 3 {
+			return
+		case "3":
+			gm.addFiles()
+		case "4":
+			gm.patchStager(patchStage)
+		case "5":
+			status, _ := gm.runGitCommand("status", "--short")
+			if status == "" {
+				status = "(aucune modification)"
+			}
+			fmt.Println(status)
+		case "6":
+			return
+		case "0":
+			gm.restoreAfterRebase(original)
+			gm.pause()
+			return
+		}
+	}
+}
+
+// Rebase arrêté sur le commit à découper: ses changements sont remis dans l'arbre de travail
+// et recommités en plusieurs fois avant de reprendre le rebase
+// Ajout sélectif limité aux fichiers du commit découpé (pas de 'git add .' qui embarquerait les non suivis)
+func (gm *GitManager) stageSplitFiles(paths []string) {
+	// Sortie brute: le premier code de statut peut commencer par une espace
+	status, _ := gm.rawDiff(append([]string{"status", "--porcelain", "-z", "--untracked-files=all", "--"}, paths...)...)
+	pending := []string{}
+	for _, entry := range strings.Split(status, "\x00") {
+		// "XY chemin": Y indique un changement hors stage ('?' pour un fichier non suivi)
+		if len(entry) > 3 && entry[1] != ' ' {
+			pending = append(pending, entry[3:])
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Printf("%s📋 Tout est déjà en stage.%s\n", ColorYellow, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("\n%sFichiers du commit non stagés:%s\n", ColorBlue, ColorReset)
+	for i, path := range pending {
+		fmt.Printf("%2d. %s\n", i+1, path)
+	}
+	fmt.Printf("\n%sNuméros à ajouter (ex: 1 3 5-7, all), vide pour annuler: %s", ColorYellow, ColorReset)
+	input := gm.getUserInput()
+	if input == "" {
+		return
+	}
+	indexes := parseSelection(input, len(pending))
+	if len(indexes) == 0 {
+		fmt.Printf("%s❌ Sélection invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	selected := []string{}
+	for _, index := range indexes {
+		selected = append(selected, pending[index])
+	}
+	if output, err := gm.runGitCommand(append([]string{"add", "-A", "--"}, selected...)...); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
+	fmt.Printf("%s✅ %d fichier(s) ajouté(s) au stage%s\n", ColorGreen, len(selected), ColorReset)
+}
+
+func (gm *GitManager) splitStoppedCommit(original, target string) {
+	files, _ := gm.runGitCommand("diff-tree", "-z", "--no-commit-id", "--name-only", "-r", target)
+	paths := strings.Split(strings.TrimSuffix(files, "\x00"), "\x00")
+	if output, err := gm.runGitCommand("reset", "-q", "HEAD^"); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.restoreAfterRebase(original)
+		gm.pause()
+		return
+	}
+	originalMessage, _ := gm.runGitCommand("log", "-1", "--format=%B", target)
+
+	for part := 1; ; {
+		remaining, _ := gm.runGitCommand(append([]string{"status", "--short", "--"}, paths...)...)
+		if remaining == "" {
+			break
+		}
+
+		gm.clearScreen()
+		fmt.Printf("%s%s✂️  DÉCOUPAGE DU COMMIT (partie %d)%s\n", ColorBold, ColorPurple, part, ColorReset)
+		fmt.Println(strings.Repeat("═", 40))
+		fmt.Printf("%sChangements restants:%s\n%s\n", ColorBlue, ColorReset, remaining)
+		if staged, _ := gm.runGitCommand("diff", "--cached", "--name-only"); staged != "" {
+			fmt.Printf("%sEn stage:%s\n%s\n", ColorGreen, ColorReset, staged)
+		}
+
+		fmt.Println("\n1. Ajouter des fichiers")
+		fmt.Println("2. Ajouter par morceaux (hunks)")
+		fmt.Println("3. Commiter ce qui est en stage")
+		fmt.Println("4. Commiter tout le reste")
+		fmt.Println("0. Tout annuler et restaurer la branche")
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+
+		choice := gm.getUserInput()
+		switch choice {
+		case "1":
+			gm.stageSplitFiles(paths)
+		case "2":
+			gm.patchStager(patchStage)
+		case "3", "4":
+			if choice == "4" {
+				// Limité aux fichiers du commit découpé: ceux qu'il créait redeviennent non suivis après le reset
+				gm.runGitCommand(append([]string{"add", "-A", "--"}, paths...)...)
+			}
+			if staged, _ := gm.runGitCommand("diff", "--cached", "--name-only"); staged == "" {
+				fmt.Printf("%s❌ Rien en stage!%s\n", ColorRed, ColorReset)
+				gm.pause()
+				continue
+			}
+			fmt.Printf("%sMessage d'origine:%s\n%s\n", ColorCyan, ColorReset, originalMessage)
+			message := gm.promptCommitMessage()
+			if message == "" {
+				fmt.Printf("%s❌ Message de commit requis!%s\n", ColorRed, ColorReset)
+				gm.pause()
+				continue
+			}
+			if output, err := gm.runGitCommand("commit", "-m", message); err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				gm.pause()
+				continue
+			}
+			part++
+		case "0":
+			gm.restoreAfterRebase(original)
+			gm.pause()
+			return
+		}
+	}
+
+	if gm.continueRewrite(original) {
+		fmt.Printf("%s✅ Commit découpé et historique réécrit!%s\n", ColorGreen, ColorReset)
+		result, _ := gm.runGitCommand("log", "--oneline", "-10")
+		fmt.Println(result)
+	}
+	gm.pause()
+}
+
+// Remote Management
+func (gm *GitManager) addRemote() {
+	fmt.Printf("%sNom du remote (ex: origin): %s", ColorYellow, ColorReset)
+	name := gm.getUserInput()
+	fmt.Printf("%sURL du remote: %s", ColorYellow, ColorReset)
+	url := gm.getUserInput()
+
+	if name == "" || url == "" {
+		fmt.Printf("%s❌ Nom et URL sont requis!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	output, err := gm.runGitCommand("remote", "add", name, url)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Remote '%s' ajouté!%s\n", ColorGreen, name, ColorReset)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) removeRemote() {
+	fmt.Printf("%sNom du remote à supprimer: %s", ColorYellow, ColorReset)
+	name := gm.getUserInput()
+	if name == "" {
+		gm.pause()
+		return
+	}
+
+	output, err := gm.runGitCommand("remote", "remove", name)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Remote '%s' supprimé!%s\n", ColorGreen, name, ColorReset)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) renameRemote() {
+	fmt.Printf("%sAncien nom du remote: %s", ColorYellow, ColorReset)
+	oldName := gm.getUserInput()
+	fmt.Printf("%sNouveau nom du remote: %s", ColorYellow, ColorReset)
+	newName := gm.getUserInput()
+
+	if oldName == "" || newName == "" {
+		fmt.Printf("%s❌ Les deux noms sont requis!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	output, err := gm.runGitCommand("remote", "rename", oldName, newName)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Remote renommé de '%s' à '%s'!%s\n", ColorGreen, oldName, newName, ColorReset)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) fetchFromRemote() {
+	fmt.Printf("%sNom du remote (laisser vide pour 'all'): %s", ColorYellow, ColorReset)
+	remote := gm.getUserInput()
+
+	var output string
+	var err error
+	if remote == "" {
+		output, err = gm.runGitCommand("fetch", "--all", "--prune")
+	} else {
+		output, err = gm.runGitCommand("fetch", remote, "--prune")
+	}
+
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Fetch terminé!%s\n", ColorGreen, ColorReset)
+		fmt.Println(output)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) pullFromRemote() {
+	fmt.Printf("%sRemote (défaut 'origin'): %s", ColorYellow, ColorReset)
+	remote := gm.getUserInput()
+	if remote == "" {
+		remote = "origin"
+	}
+
+	currentBranch := gm.getCurrentBranch()
+	fmt.Printf("%sBranche (défaut '%s'): %s", ColorYellow, currentBranch, ColorReset)
+	branch := gm.getUserInput()
+	if branch == "" {
+		branch = currentBranch
+	}
+
+	gm.pullWithStrategy(remote, branch)
+	gm.pause()
+}
+
+// Mode de pull par défaut: gitman.pullMode, sinon déduit de pull.rebase / pull.ff
+func (gm *GitManager) getDefaultPullMode() string {
+	switch mode := gm.getGitConfig("gitman.pullMode"); mode {
+	case "merge", "rebase", "ff-only":
+		return mode
+	}
+
+	switch gm.getGitConfig("pull.rebase") {
+	case "true", "merges", "interactive", "i", "m":
+		return "rebase"
+	}
+	if gm.getGitConfig("pull.ff") == "only" {
+		return "ff-only"
+	}
+	return "merge"
+}
+
+// Pull avec aperçu des commits entrants, choix de la stratégie et autostash
+func (gm *GitManager) pullWithStrategy(remote, branch string) {
+	fmt.Printf("%s🔄 Fetch de %s/%s...%s\n", ColorYellow, remote, branch, ColorReset)
+	if output, err := gm.runGitCommand("fetch", remote, branch); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		return
+	}
+
+	// FETCH_HEAD pointe exactement sur ce qui va être intégré
+	incoming, _ := gm.runGitCommand("log", "--oneline", "--no-decorate", "HEAD..FETCH_HEAD")
+	if incoming == "" {
+		fmt.Printf("%s✅ Déjà à jour avec %s/%s.%s\n", ColorGreen, remote, branch, ColorReset)
+		return
+	}
+	fmt.Printf("%s📥 Commits entrants (%d):%s\n", ColorBlue, len(strings.Split(incoming, "\n")), ColorReset)
+	fmt.Println(incoming)
+
+	outgoing, _ := gm.runGitCommand("rev-list", "--count", "FETCH_HEAD..HEAD")
+	if outgoing != "" && outgoing != "0" {
+		fmt.Printf("%s⚠️  Les branches ont divergé: %s commit(s) local(aux) non présent(s) sur le remote.%s\n", ColorYellow, outgoing, ColorReset)
+	}
+
+	mode := gm.getDefaultPullMode()
+	fmt.Printf("\n%sStratégie: 1. merge  2. rebase  3. fast-forward uniquement (défaut %s): %s", ColorYellow, mode, ColorReset)
+	switch gm.getUserInput() {
+	case "1":
+		mode = "merge"
+	case "2":
+		mode = "rebase"
+	case "3":
+		mode = "ff-only"
+	}
+
+	args := []string{"pull"}
+	switch mode {
+	case "rebase":
+		args = append(args, "--rebase")
+	case "ff-only":
+		args = append(args, "--ff-only")
+	default:
+		args = append(args, "--no-rebase", "--no-edit")
+	}
+
+	if status, _ := gm.runGitCommand("status", "--porcelain", "--untracked-files=no"); status != "" && mode != "ff-only" {
+		fmt.Printf("%s⚠️  Modifications locales détectées. Utiliser --autostash? (y/N): %s", ColorYellow, ColorReset)
+		if strings.ToLower(gm.getUserInput()) == "y" {
+			args = append(args, "--autostash")
+		}
+	} else if status != "" {
+		fmt.Printf("%s💡 Modifications locales détectées: elles seront conservées si elles ne touchent pas les fichiers entrants.%s\n", ColorCyan, ColorReset)
+	}
+	args = append(args, remote, branch)
+
+	before, _ := gm.runGitCommand("rev-parse", "HEAD")
+	fetched, _ := gm.runGitCommand("rev-parse", "FETCH_HEAD")
+
+	output, err := gm.runGitCommand(args...)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		if mode == "ff-only" {
+			fmt.Printf("%s💡 Fast-forward impossible: choisissez merge ou rebase.%s\n", ColorYellow, ColorReset)
+		}
+		if operation := gm.getOperationInProgress(); operation != "" {
+			gm.pause()
+			gm.handleOperationInProgress()
+		}
+		return
+	}
+
+	fmt.Printf("%s✅ Pull terminé (%s)!%s\n", ColorGreen, mode, ColorReset)
+	received, _ := gm.runGitCommand("log", "--oneline", "--no-decorate", before+".."+fetched)
+	if received != "" {
+		fmt.Printf("%s📦 Commits reçus:%s\n", ColorBlue, ColorReset)
+		fmt.Println(received)
+	}
+}
+
+func (gm *GitManager) pushToRemote() {
+	fmt.Printf("%sRemote (défaut 'origin'): %s", ColorYellow, ColorReset)
+	remote := gm.getUserInput()
+	if remote == "" {
+		remote = "origin"
+	}
+
+	currentBranch := gm.getCurrentBranch()
+	fmt.Printf("%sBranche (défaut '%s'): %s", ColorYellow, currentBranch, ColorReset)
+	branch := gm.getUserInput()
+	if branch == "" {
+		branch = currentBranch
+	}
+
+	args := []string{"push"}
+	if gm.getUpstream(branch) == "" {
+		fmt.Printf("%s🔌 '%s' n'a pas d'upstream. Le définir sur %s/%s (--set-upstream)? (y/N): %s", ColorYellow, branch, remote, branch, ColorReset)
+		if strings.ToLower(gm.getUserInput()) == "y" {
+			args = append(args, "--set-upstream")
+		}
+	}
+
+	fmt.Printf("%sForcer le push (--force-with-lease)? (y/N): %s", ColorRed, ColorReset)
+	force := gm.getUserInput()
+
+	args = append(args, remote, branch)
+	if strings.ToLower(force) == "y" {
+		if !gm.confirmForcePush(remote, branch) {
+			gm.pause()
+			return
+		}
+		args = append(args, "--force-with-lease", "--force-if-includes")
+	}
+
+	output, err := gm.runGitCommand(args...)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Push terminé!%s\n", ColorGreen, ColorReset)
+		fmt.Println(output)
+	}
+	gm.pause()
+}
+
+// Montre les commits du remote qui seront écrasés par un push forcé et demande confirmation
+func (gm *GitManager) confirmForcePush(remote, branch string) bool {
+	if !gm.confirmProtectedAction(branch, "push forcé") {
+		return false
+	}
+
+	gm.runGitCommand("fetch", remote, branch)
+	remoteRef := "refs/remotes/" + remote + "/" + branch
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", remoteRef); err != nil {
+		fmt.Printf("%s💡 '%s/%s' n'existe pas encore sur le remote, rien ne sera écrasé.%s\n", ColorCyan, remote, branch, ColorReset)
+		return true
+	}
+
+	overwritten, _ := gm.runGitCommand("log", "--oneline", "--no-decorate", branch+".."+remoteRef)
+	if overwritten == "" {
+		fmt.Printf("%s✅ Aucun commit distant ne sera écrasé.%s\n", ColorGreen, ColorReset)
+		return true
+	}
+
+	fmt.Printf("%s⚠️  Commits présents sur %s/%s qui seront ÉCRASÉS:%s\n", ColorRed, remote, branch, ColorReset)
+	fmt.Println(overwritten)
+	fmt.Printf("\n%sConfirmer le push forcé? (y/N): %s", ColorRed, ColorReset)
+	return strings.ToLower(gm.getUserInput()) == "y"
+}
+
+// File Management
+func (gm *GitManager) printFileStatus(line string) {
+	if len(line) < 3 {
+		return
+	}
+
+	status := line[:2]
+	file := line[3:]
+
+	switch status {
+	case "M ":
+		fmt.Printf("%s  M  %s%s (modifié, en stage)\n", ColorGreen, file, ColorReset)
+	case " M":
+		fmt.Printf("%s  M  %s%s (modifié)\n", ColorYellow, file, ColorReset)
+	case "A ":
+		fmt.Printf("%s  A  %s%s (ajouté)\n", ColorGreen, file, ColorReset)
+	case "D ":
+		fmt.Printf("%s  D  %s%s (supprimé)\n", ColorRed, file, ColorReset)
+	case "??":
+		fmt.Printf("%s  ?  %s%s (non suivi)\n", ColorRed, file, ColorReset)
+	case "R ":
+		fmt.Printf("%s  R  %s%s (renommé)\n", ColorCyan, file, ColorReset)
+	default:
+		fmt.Printf("%s  %s  %s%s\n", ColorWhite, status, file, ColorReset)
+	}
+}
+
+func (gm *GitManager) addFiles() {
+	status := gm.getGitStatus()
+	if status == "" {
+		fmt.Printf("%s✅ Aucun fichier à ajouter!%s\n", ColorGreen, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s📁 Fichiers disponibles:%s\n", ColorYellow, ColorReset)
+	lines := strings.Split(status, "\n")
+	for _, line := range lines {
+		if line != "" {
+			gm.printFileStatus(line)
+		}
+	}
+
+	fmt.Println("\n1. Ajouter tous les fichiers")
+	fmt.Println("2. Ajouter des fichiers spécifiques")
+	fmt.Println("3. Ajouter par morceaux (hunks)")
+	fmt.Println("0. Annuler")
+
+	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+
+	switch choice {
+	case "1":
+		output, err := gm.runGitCommand("add", ".")
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Tous les fichiers ajoutés!%s\n", ColorGreen, ColorReset)
+		}
+	case "2":
+		fmt.Printf("%sFichiers à ajouter (séparés par des espaces): %s", ColorYellow, ColorReset)
+		files := gm.getUserInput()
+		if files != "" {
+			fileList := strings.Fields(files)
+			for _, file := range fileList {
+				output, err := gm.runGitCommand("add", file)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
+				} else {
+					fmt.Printf("%s✅ '%s' ajouté!%s\n", ColorGreen, file, ColorReset)
+				}
+			}
+		}
+	case "3":
+		gm.patchStager(patchStage)
+		return
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) unstageFiles() {
+	staged, _ := gm.runGitCommand("diff", "--cached", "--name-only")
+	if staged == "" {
+		fmt.Printf("%s✅ Aucun fichier en stage!%s\n", ColorGreen, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s📁 Fichiers en stage:%s\n", ColorYellow, ColorReset)
+	fmt.Println(staged)
+
+	fmt.Println("\n1. Retirer tous les fichiers du staging")
+	fmt.Println("2. Retirer des fichiers spécifiques")
+	fmt.Println("3. Retirer par morceaux (hunks)")
+	fmt.Println("0. Annuler")
+
+	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+
+	switch choice {
+	case "1":
+		output, err := gm.runGitCommand("reset", "HEAD")
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Tous les fichiers retirés du staging!%s\n", ColorGreen, ColorReset)
+		}
+	case "2":
+		fmt.Printf("%sFichiers à retirer (séparés par des espaces): %s", ColorYellow, ColorReset)
+		files := gm.getUserInput()
+		if files != "" {
+			fileList := strings.Fields(files)
+			for _, file := range fileList {
+				output, err := gm.runGitCommand("reset", "HEAD", "--", file)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
+				} else {
+					fmt.Printf("%s✅ '%s' retiré du staging!%s\n", ColorGreen, file, ColorReset)
+				}
+			}
+		}
+	case "3":
+		gm.patchStager(patchUnstage)
+		return
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) showDiff() {
+	for {
+		gm.clearScreen()
+		fmt.Println("1. Voir les changements non stagés")
+		fmt.Println("2. Voir les changements stagés")
+		fmt.Println("3. Voir les changements d'un fichier spécifique")
+		fmt.Println("4. Comparer deux commits")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			// Ajout de l'option --color=always pour forcer la coloration
+			output, _ := gm.runGitCommand("diff", "--color=always")
+			if output == "" {
+				fmt.Printf("%s✅ Aucun changement non stagé!%s\n", ColorGreen, ColorReset)
+			} else {
+				fmt.Printf("%s📊 Changements non stagés:%s\n", ColorBlue, ColorReset)
+				fmt.Println(output)
+			}
+			gm.pause()
+		case "2":
+			// Ajout de l'option --color=always pour forcer la coloration
+			output, _ := gm.runGitCommand("diff", "--cached", "--color=always")
+			if output == "" {
+				fmt.Printf("%s✅ Aucun changement stagé!%s\n", ColorGreen, ColorReset)
+			} else {
+				fmt.Printf("%s📊 Changements stagés:%s\n", ColorBlue, ColorReset)
+				fmt.Println(output)
+			}
+			gm.pause()
+		case "3":
+			fmt.Printf("%sNom du fichier: %s", ColorYellow, ColorReset)
+			filename := gm.getUserInput()
+			if filename != "" {
+				// Ajout de l'option --color=always pour forcer la coloration
+				output, err := gm.runGitCommand("diff", "--color=always", "--", filename)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				} else {
+					fmt.Printf("%s📊 Changements dans '%s':%s\n", ColorBlue, filename, ColorReset)
+					fmt.Println(output)
+				}
+			}
+			gm.pause()
+		case "4":
+			fmt.Printf("%sPremier commit: %s", ColorYellow, ColorReset)
+			commit1 := gm.getUserInput()
+			fmt.Printf("%sDeuxième commit: %s", ColorYellow, ColorReset)
+			commit2 := gm.getUserInput()
+
+			if commit1 != "" && commit2 != "" {
+				// Ajout de l'option --color=always pour forcer la coloration
+				output, err := gm.runGitCommand("diff", "--color=always", commit1, commit2)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				} else {
+					fmt.Printf("%s📊 Différences entre '%s' et '%s':%s\n", ColorBlue, commit1, commit2, ColorReset)
+					fmt.Println(output)
+				}
+			}
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) manageGitignore() {
+	gitignorePath := filepath.Join(gm.currentPath, ".gitignore")
+
+	for {
+		gm.clearScreen()
+		fmt.Println("1. Voir le contenu de .gitignore")
+		fmt.Println("2. Ajouter des patterns à .gitignore")
+		fmt.Println("3. Créer un .gitignore basique")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			content, err := os.ReadFile(gitignorePath)
+			if err != nil {
+				fmt.Printf("%s❌ Fichier .gitignore introuvable ou erreur de lecture%s\n", ColorRed, ColorReset)
+			} else {
+				fmt.Printf("%s📄 Contenu de .gitignore:%s\n", ColorBlue, ColorReset)
+				fmt.Println(string(content))
+			}
+			gm.pause()
+		case "2":
+			fmt.Printf("%sPatterns à ajouter (un par ligne, ligne vide pour terminer):%s\n", ColorYellow, ColorReset)
+			var patterns []string
+			for {
+				fmt.Print("> ")
+				line := gm.getUserInput()
+				if line == "" {
+					break
+				}
+				patterns = append(patterns, line)
+			}
+
+			if len(patterns) > 0 {
+				file, err := os.OpenFile(gitignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur lors de l'ouverture du fichier: %s%s\n", ColorRed, err.Error(), ColorReset)
+				} else {
+					defer file.Close()
+					for _, pattern := range patterns {
+						_, _ = file.WriteString(pattern + "\n")
+					}
+					fmt.Printf("%s✅ Patterns ajoutés à .gitignore!%s\n", ColorGreen, ColorReset)
+				}
+			}
+			gm.pause()
+		case "3":
+			basicGitignore := `# Fichiers de compilation
+*.o
+*.so
+*.dylib
+*.exe
+
+# Fichiers de debug
+*.dSYM/
+*.pdb
+
+# Fichiers temporaires
+*.tmp
+*.temp
+*~
+.DS_Store
+Thumbs.db
+
+# Répertoires de dépendances
+node_modules/
+vendor/
+
+# Fichiers de configuration locale
+.env
+.env.local
+config.local.*
+
+# Logs
+*.log
+logs/
+
+# Fichiers d'IDE
+.vscode/
+.idea/
+*.swp
+*.swo
+`
+			err := os.WriteFile(gitignorePath, []byte(basicGitignore), 0644)
+			if err != nil {
+				fmt.Printf("%s❌ Erreur lors de la création: %s%s\n", ColorRed, err.Error(), ColorReset)
+			} else {
+				fmt.Printf("%s✅ .gitignore basique créé!%s\n", ColorGreen, ColorReset)
+			}
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) restoreFiles() {
+	status := gm.getGitStatus()
+	if status == "" {
+		fmt.Printf("%s✅ Aucun fichier à restaurer!%s\n", ColorGreen, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s📁 Fichiers modifiés:%s\n", ColorYellow, ColorReset)
+	lines := strings.Split(status, "\n")
+	for _, line := range lines {
+		if line != "" {
+			gm.printFileStatus(line)
+		}
+	}
+
+	fmt.Println("\n1. Restaurer tous les fichiers modifiés")
+	fmt.Println("2. Restaurer des fichiers spécifiques")
+	fmt.Println("3. Annuler des modifications par morceaux (hunks)")
+	fmt.Println("0. Annuler")
+
+	fmt.Printf("\n%s⚠️  ATTENTION: Cette action va perdre les modifications non commitées!%s\n", ColorRed, ColorReset)
+	fmt.Printf("%sChoisissez une option: %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+
+	switch choice {
+	case "1":
+		fmt.Printf("%sÊtes-vous sûr? (y/N): %s", ColorRed, ColorReset)
+		confirm := gm.getUserInput()
+		if strings.ToLower(confirm) == "y" {
+			output, err := gm.runGitCommand("checkout", "--", ".")
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				fmt.Printf("%s✅ Tous les fichiers restaurés!%s\n", ColorGreen, ColorReset)
+			}
+		}
+	case "2":
+		fmt.Printf("%sFichiers à restaurer (séparés par des espaces): %s", ColorYellow, ColorReset)
+		files := gm.getUserInput()
+		if files != "" {
+			fmt.Printf("%sÊtes-vous sûr? (y/N): %s", ColorRed, ColorReset)
+			confirm := gm.getUserInput()
+			if strings.ToLower(confirm) == "y" {
+				fileList := strings.Fields(files)
+				for _, file := range fileList {
+					output, err := gm.runGitCommand("checkout", "--", file)
+					if err != nil {
+						fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
+					} else {
+						fmt.Printf("%s✅ '%s' restauré!%s\n", ColorGreen, file, ColorReset)
+					}
+				}
+			}
+		}
+	case "3":
+		gm.patchStager(patchDiscard)
+		return
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) untrackFiles() {
+	fmt.Printf("%sFichiers à ne plus tracker (séparés par des espaces): %s", ColorYellow, ColorReset)
+	files := gm.getUserInput()
+
+	if files != "" {
+		fmt.Printf("%sGarder les fichiers localement? (Y/n): %s", ColorYellow, ColorReset)
+		keep := gm.getUserInput()
+
+		fileList := strings.Fields(files)
+		for _, file := range fileList {
+			var output string
+			var err error
+
+			if strings.ToLower(keep) == "n" {
+				output, err = gm.runGitCommand("rm", file)
+			} else {
+				output, err = gm.runGitCommand("rm", "--cached", file)
+			}
+
+			if err != nil {
+				fmt.Printf("%s❌ Erreur avec '%s': %s%s\n", ColorRed, file, output, ColorReset)
+			} else {
+				fmt.Printf("%s✅ '%s' retiré du tracking!%s\n", ColorGreen, file, ColorReset)
+			}
+		}
+	}
+	gm.pause()
+}
+
+// Patch Staging
+// Morceau (hunk) d'un diff: en-tête @@ et lignes ' ', '-', '+' ou '\'
+type patchHunk struct {
+	oldStart int
+	newStart int
+	section  string
+	lines    []string
+}
+
+// Diff d'un fichier: en-tête (diff --git, index, ---, +++) et morceaux
+type filePatch struct {
+	path   string
+	header []string
+	hunks  []*patchHunk
+	binary bool
+}
+
+// Unité proposée à l'utilisateur: un morceau entier ou une partie après découpage
+type hunkUnit struct {
+	file     *filePatch
+	hunk     *patchHunk
+	changes  []int
+	selected bool
+}
+
+// Mode du stager: source du diff et manière d'appliquer la sélection
+type patchMode struct {
+	verb      string
+	done      string
+	diffArgs  []string
+	applyArgs []string
+	reverse   bool
+}
+
+var (
+	patchStage   = &patchMode{"indexer", "indexé(s)", []string{"diff"}, []string{"apply", "--cached"}, false}
+	patchUnstage = &patchMode{"retirer de l'index", "retiré(s) de l'index", []string{"diff", "--cached"}, []string{"apply", "--cached", "--reverse"}, true}
+	patchDiscard = &patchMode{"annuler", "annulé(s)", []string{"diff"}, []string{"apply", "--reverse"}, true}
+)
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@(.*)$`)
+
+// Diff brut (sans TrimSpace: une ligne de contexte vide en fin de diff est significative)
+func (gm *GitManager) rawDiff(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = gm.currentPath
+	output, err := cmd.Output()
+	return string(output), err
+}
+
+func parsePatch(diff string) []*filePatch {
+	var files []*filePatch
+	var file *filePatch
+	var hunk *patchHunk
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			file = &filePatch{header: []string{line}}
+			if _, b, ok := strings.Cut(line, " b/"); ok {
+				file.path = b
+			}
+			files = append(files, file)
+			hunk = nil
+		case file == nil:
+			continue
+		case strings.HasPrefix(line, "@@"):
+			match := hunkHeader.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			oldStart, _ := strconv.Atoi(match[1])
+			newStart, _ := strconv.Atoi(match[2])
+			hunk = &patchHunk{oldStart: oldStart, newStart: newStart, section: match[3]}
+			file.hunks = append(file.hunks, hunk)
+		case hunk != nil:
+			hunk.lines = append(hunk.lines, line)
+		default:
+			if strings.HasPrefix(line, "+++ b/") {
+				file.path = strings.TrimPrefix(line, "+++ b/")
+			}
+			if strings.HasPrefix(line, "Binary files") || line == "GIT binary patch" {
+				file.binary = true
+			}
+			file.header = append(file.header, line)
+		}
+	}
+	return files
+}
+
+// Indices des lignes modifiées (+/-) d'un morceau
+func (h *patchHunk) changeIndexes() []int {
+	var changes []int
+	for i, line := range h.lines {
+		if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			changes = append(changes, i)
+		}
+	}
+	return changes
+}
+
+// Découpe une unité en groupes de modifications séparés par du contexte
+func (u *hunkUnit) split() []*hunkUnit {
+	var units []*hunkUnit
+	for i, index := range u.changes {
+		if i == 0 || index != u.changes[i-1]+1 && u.hasContextBetween(u.changes[i-1], index) {
+			units = append(units, &hunkUnit{file: u.file, hunk: u.hunk})
+		}
+		current := units[len(units)-1]
+		current.changes = append(current.changes, index)
+	}
+	return units
+}
+
+func (u *hunkUnit) hasContextBetween(from, to int) bool {
+	for i := from + 1; i < to; i++ {
+		if strings.HasPrefix(u.hunk.lines[i], " ") {
+			return true
+		}
+	}
+	return false
+}
+
+// Affiche l'unité avec 3 lignes de contexte autour de ses modifications
+func (u *hunkUnit) print() {
+	first, last := u.changes[0], u.changes[len(u.changes)-1]
+	from, to := first-3, last+3
+	if from < 0 {
+		from = 0
+	}
+	if to >= len(u.hunk.lines) {
+		to = len(u.hunk.lines) - 1
+	}
+
+	inUnit := make(map[int]bool)
+	for _, index := range u.changes {
+		inUnit[index] = true
+	}
+
+	fmt.Printf("%s@@ -%d +%d @@%s%s\n", ColorCyan, u.hunk.oldStart, u.hunk.newStart, u.hunk.section, ColorReset)
+	for i := from; i <= to; i++ {
+		line := u.hunk.lines[i]
+		switch {
+		case strings.HasPrefix(line, "+") && inUnit[i]:
+			fmt.Printf("%s%s%s\n", ColorGreen, line, ColorReset)
+		case strings.HasPrefix(line, "-") && inUnit[i]:
+			fmt.Printf("%s%s%s\n", ColorRed, line, ColorReset)
+		case strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-"):
+			// Modification d'une autre partie du morceau
+			fmt.Printf("%s%s%s\n", ColorWhite, line, ColorReset)
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+// Construit le patch ne contenant que les lignes sélectionnées.
+// Une ligne non sélectionnée devient du contexte si elle existe du côté auquel le patch s'applique,
+// sinon elle est retirée (en sens inverse, les rôles de '+' et '-' sont échangés).
+func buildPartialPatch(files []*filePatch, units []*hunkUnit, reverse bool) string {
+	selected := make(map[*patchHunk]map[int]bool)
+	for _, unit := range units {
+		if !unit.selected {
+			continue
+		}
+		if selected[unit.hunk] == nil {
+			selected[unit.hunk] = make(map[int]bool)
+		}
+		for _, index := range unit.changes {
+			selected[unit.hunk][index] = true
+		}
+	}
+
+	keep, drop := "-", "+"
+	if reverse {
+		keep, drop = "+", "-"
+	}
+
+	var patch strings.Builder
+	for _, file := range files {
+		var hunks []string
+		for _, hunk := range file.hunks {
+			chosen := selected[hunk]
+			if len(chosen) == 0 {
+				continue
+			}
+
+			var lines []string
+			oldCount, newCount := 0, 0
+			dropped := false
+			for i, line := range hunk.lines {
+				if strings.HasPrefix(line, "\\") {
+					if !dropped {
+						lines = append(lines, line)
+					}
+					continue
+				}
+				dropped = false
+				switch {
+				case strings.HasPrefix(line, " "), line == "":
+					lines = append(lines, " "+strings.TrimPrefix(line, " "))
+					oldCount++
+					newCount++
+				case chosen[i]:
+					lines = append(lines, line)
+					if strings.HasPrefix(line, "-") {
+						oldCount++
+					} else {
+						newCount++
+					}
+				case strings.HasPrefix(line, drop):
+					dropped = true
+				case strings.HasPrefix(line, keep):
+					lines = append(lines, " "+line[1:])
+					oldCount++
+					newCount++
+				}
+			}
+			hunks = append(hunks, fmt.Sprintf("@@ -%d,%d +%d,%d @@%s", hunk.oldStart, oldCount, hunk.newStart, newCount, hunk.section))
+			hunks = append(hunks, lines...)
+		}
+		if len(hunks) == 0 {
+			continue
+		}
+		patch.WriteString(strings.Join(file.header, "\n") + "\n")
+		patch.WriteString(strings.Join(hunks, "\n") + "\n")
+	}
+	return patch.String()
+}
+
+// Sélection interactive de morceaux; retourne false si l'utilisateur a annulé
+func (gm *GitManager) selectHunks(units []*hunkUnit, mode *patchMode) ([]*hunkUnit, bool) {
+	for i := 0; i < len(units); {
+		unit := units[i]
+		gm.clearScreen()
+		fmt.Printf("%s%s📄 %s%s  %s(morceau %d/%d)%s\n", ColorBold, ColorBlue, unit.file.path, ColorReset, ColorCyan, i+1, len(units), ColorReset)
+		fmt.Println(strings.Repeat("─", 50))
+		unit.print()
+		fmt.Println(strings.Repeat("─", 50))
+
+		canSplit := len(unit.split()) > 1
+		fmt.Printf("%sy%s %s  %sn%s passer  ", ColorGreen, ColorReset, mode.verb, ColorYellow, ColorReset)
+		if canSplit {
+			fmt.Printf("%ss%s découper  ", ColorCyan, ColorReset)
+		}
+		fmt.Printf("%sa%s tout le fichier  %sd%s passer le fichier  %sk%s précédent  %sq%s terminer  %sx%s annuler\n",
+			ColorGreen, ColorReset, ColorYellow, ColorReset, ColorCyan, ColorReset, ColorBlue, ColorReset, ColorRed, ColorReset)
+		fmt.Printf("%s%s ce morceau? %s", ColorYellow, strings.ToUpper(mode.verb[:1])+mode.verb[1:], ColorReset)
+
+		choice := strings.ToLower(gm.getUserInput())
+		switch choice {
+		case "y":
+			unit.selected = true
+			i++
+		case "n":
+			unit.selected = false
+			i++
+		case "s":
+			if !canSplit {
+				continue
+			}
+			parts := unit.split()
+			units = append(units[:i], append(parts, units[i+1:]...)...)
+		case "a", "d":
+			file := unit.file
+			for i < len(units) && units[i].file == file {
+				units[i].selected = choice == "a"
+				i++
+			}
+		case "k":
+			if i > 0 {
+				i--
+			}
+		case "q":
+			return units, true
+		case "x":
+			return units, false
+		}
+	}
+	return units, true
+}
+
+// Stager interactif par morceaux: indexer, retirer de l'index ou annuler des modifications
+func (gm *GitManager) patchStager(mode *patchMode) {
+	fmt.Printf("%sFichier ou dossier (vide pour tous): %s", ColorYellow, ColorReset)
+	path := gm.getUserInput()
+
+	args := append([]string{}, mode.diffArgs...)
+	args = append(args, "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/")
+	if path != "" {
+		args = append(args, "--", path)
+	}
+
+	diff, err := gm.rawDiff(args...)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur lors de la lecture du diff%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	files := parsePatch(diff)
+	var units []*hunkUnit
+	for _, file := range files {
+		if file.binary {
+			fmt.Printf("%s⚠️  Fichier binaire ignoré: %s%s\n", ColorYellow, file.path, ColorReset)
+			continue
+		}
+		for _, hunk := range file.hunks {
+			units = append(units, &hunkUnit{file: file, hunk: hunk, changes: hunk.changeIndexes()})
+		}
+	}
+
+	if len(units) == 0 {
+		fmt.Printf("%s✅ Aucun morceau à %s!%s\n", ColorGreen, mode.verb, ColorReset)
+		if mode == patchStage {
+			fmt.Printf("%s💡 Les fichiers non suivis doivent d'abord être ajoutés entièrement%s\n", ColorCyan, ColorReset)
+		}
+		gm.pause()
+		return
+	}
+
+	units, ok := gm.selectHunks(units, mode)
+	count := 0
+	for _, unit := range units {
+		if unit.selected {
+			count++
+		}
+	}
+	if !ok || count == 0 {
+		fmt.Printf("%sAucune modification appliquée.%s\n", ColorYellow, ColorReset)
+		gm.pause()
+		return
+	}
+
+	if mode == patchDiscard {
+		fmt.Printf("%s⚠️  %d morceau(x) seront définitivement perdus. Confirmer? (y/N): %s", ColorRed, count, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
+			fmt.Printf("%sAnnulé.%s\n", ColorYellow, ColorReset)
+			gm.pause()
+			return
+		}
+	}
+
+	patch := buildPartialPatch(files, units, mode.reverse)
+	applyArgs := append(append([]string{}, mode.applyArgs...), "--recount", "--whitespace=nowarn", "-")
+	output, err := gm.runGitCommandWithInput(patch, applyArgs...)
+	if err != nil {
+		fmt.Printf("%s❌ Impossible d'appliquer la sélection: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ %d morceau(x) %s!%s\n", ColorGreen, count, mode.done, ColorReset)
+	}
+	gm.pause()
+}
+
+// Tag Management
+func (gm *GitManager) createTag() {
+	fmt.Printf("%sNom du tag: %s", ColorYellow, ColorReset)
+	tagName := gm.getUserInput()
+
+	if tagName == "" {
+		fmt.Printf("%s❌ Nom de tag requis!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%sCommit à tagger (vide pour HEAD): %s", ColorYellow, ColorReset)
+	commit := gm.getUserInput()
+
+	var output string
+	var err error
+
+	if commit == "" {
+		// Avec tag.gpgSign, git transformerait le tag léger en tag signé et ouvrirait un éditeur
+		output, err = gm.runGitCommand("-c", "tag.gpgSign=false", "tag", tagName)
+	} else {
+		output, err = gm.runGitCommand("-c", "tag.gpgSign=false", "tag", tagName, commit)
+	}
+
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Tag '%s' créé!%s\n", ColorGreen, tagName, ColorReset)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) createAnnotatedTag() {
+	fmt.Printf("%sNom du tag: %s", ColorYellow, ColorReset)
+	tagName := gm.getUserInput()
+
+	if tagName == "" {
+		fmt.Printf("%s❌ Nom de tag requis!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	comments := []string{
+		fmt.Sprintf("Saisissez le message du tag '%s'. Les lignes commençant par '%s' sont ignorées.", tagName, gm.commentChar()),
+	}
+	message, _ := gm.promptMessage("Message du tag", "", comments)
+
+	if message == "" {
+		fmt.Printf("%s❌ Message requis pour un tag annoté!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%sCommit à tagger (vide pour HEAD): %s", ColorYellow, ColorReset)
+	commit := gm.getUserInput()
+
+	var output string
+	var err error
+
+	if commit == "" {
+		output, err = gm.runGitCommand("tag", "-a", tagName, "-m", message)
+	} else {
+		output, err = gm.runGitCommand("tag", "-a", tagName, "-m", message, commit)
+	}
+
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Tag annoté '%s' créé!%s\n", ColorGreen, tagName, ColorReset)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) deleteTag() {
+	tags, _ := gm.runGitCommand("tag", "-l")
+	if tags == "" {
+		fmt.Printf("%s❌ Aucun tag à supprimer!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s🏷️  Tags disponibles:%s\n", ColorBlue, ColorReset)
+	fmt.Println(tags)
+
+	fmt.Printf("\n%sNom du tag à supprimer: %s", ColorYellow, ColorReset)
+	tagName := gm.getUserInput()
+
+	if tagName == "" {
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s⚠️  Êtes-vous sûr de vouloir supprimer le tag '%s'? (y/N): %s", ColorRed, tagName, ColorReset)
+	confirm := gm.getUserInput()
+
+	if strings.ToLower(confirm) == "y" {
+		output, err := gm.runGitCommand("tag", "-d", tagName)
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Tag '%s' supprimé!%s\n", ColorGreen, tagName, ColorReset)
+
+			// Le tag reste publié tant qu'il n'est pas supprimé sur le remote
+			if remoteTags, err := gm.remoteTags("origin"); err == nil && remoteTags[tagName] != "" {
+				fmt.Printf("%s💡 Le tag existe aussi sur 'origin'.%s\n", ColorYellow, ColorReset)
+				gm.deleteRemoteTag("origin", tagName)
+			}
+		}
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) showTagDetails() {
+	fmt.Printf("%sNom du tag: %s", ColorYellow, ColorReset)
+	tagName := gm.getUserInput()
+
+	if tagName == "" {
+		gm.pause()
+		return
+	}
+
+	output, err := gm.runGitCommand("show", tagName)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s🏷️  Détails du tag '%s':%s\n", ColorBlue, tagName, ColorReset)
+		fmt.Println(output)
+	}
+	gm.pause()
+}
+
+// Tag affiché dans le navigateur de tags
+type tagInfo struct {
+	name      string
+	annotated bool
+	tagger    string
+	date      string
+	timestamp int64
+	subject   string
+	target    string
+}
+
+func (gm *GitManager) loadTags() []tagInfo {
+	format := strings.Join([]string{"%(refname:strip=2)", "%(objecttype)", "%(taggername)", "%(authorname)",
+		"%(creatordate:short)", "%(creatordate:unix)", "%(contents:subject)", "%(*objectname:short)", "%(objectname:short)"}, "%1f")
+	output, _ := gm.runGitCommand("for-each-ref", "--format="+format, "refs/tags")
+
+	var tags []tagInfo
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 9 {
+			continue
+		}
+		tag := tagInfo{name: fields[0], annotated: fields[1] == "tag", tagger: fields[2], date: fields[4], subject: fields[6], target: fields[7]}
+		tag.timestamp, _ = strconv.ParseInt(fields[5], 10, 64)
+		if !tag.annotated {
+			// Tag léger: auteur et sujet du commit pointé
+			tag.tagger, tag.target = fields[3], fields[8]
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// Tri par version (semver décroissant, tags non semver ensuite) ou par date décroissante
+func sortTags(tags []tagInfo, byDate bool) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		left, leftOK := parseSemver(tags[i].name)
+		right, rightOK := parseSemver(tags[j].name)
+		switch {
+		case leftOK && rightOK:
+			if cmp := compareSemver(left, right); cmp != 0 {
+				return cmp > 0
+			}
+		case leftOK != rightOK:
+			return leftOK
+		}
+		return tags[i].name > tags[j].name
+	})
+	if byDate {
+		// Tri stable: à date égale, l'ordre des versions est conservé
+		sort.SliceStable(tags, func(i, j int) bool {
+			return tags[i].timestamp > tags[j].timestamp
+		})
+	}
+}
+
+func (gm *GitManager) listTags() {
+	byDate := false
+	for {
+		gm.clearScreen()
+		sortLabel := "version"
+		if byDate {
+			sortLabel = "date"
+		}
+		fmt.Printf("%s%s🏷️  TAGS (tri par %s)%s\n", ColorBold, ColorBlue, sortLabel, ColorReset)
+		fmt.Println(strings.Repeat("═", 80))
+
+		tags := gm.loadTags()
+		if len(tags) == 0 {
+			fmt.Printf("%s❌ Aucun tag trouvé%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+		sortTags(tags, byDate)
+
+		for i, tag := range tags {
+			kind := ColorCyan + "léger " + ColorReset
+			if tag.annotated {
+				kind = ColorGreen + "annoté" + ColorReset
+			}
+			fmt.Printf("%3d. %s%-16s%s %s %s %s%s%s %s%-14s%s %s\n", i+1, ColorBold, tag.name, ColorReset, kind,
+				tag.date, ColorYellow, tag.target, ColorReset, ColorPurple, tag.tagger, ColorReset, tag.subject)
+			if tag.annotated {
+				if code := gm.tagSignatureStatus(tag.name); code != "N" {
+					fmt.Printf("       %s %s\n", signatureBadge(code), signatureLabel(code))
+				}
+			}
+		}
+
+		fmt.Printf("\n%sv%s tri par version  %st%s tri par date  %ss <n>%s détails  %sc <n> <m>%s diff de release  %sq%s retour\n",
+			ColorGreen, ColorReset, ColorGreen, ColorReset, ColorBlue, ColorReset, ColorCyan, ColorReset, ColorWhite, ColorReset)
+		fmt.Printf("%sAction: %s", ColorYellow, ColorReset)
+
+		fields := strings.Fields(gm.getUserInput())
+		if len(fields) == 0 {
+			continue
+		}
+		var selected []string
+		for _, field := range fields[1:] {
+			index, err := strconv.Atoi(field)
+			if err != nil || index < 1 || index > len(tags) {
+				selected = nil
+				break
+			}
+			selected = append(selected, tags[index-1].name)
+		}
+
+		switch {
+		case fields[0] == "q" || fields[0] == "0":
+			return
+		case fields[0] == "v":
+			byDate = false
+		case fields[0] == "t":
+			byDate = true
+		case fields[0] == "s" && len(selected) == 1:
+			output, _ := gm.runGitCommand("show", "--stat", selected[0])
+			fmt.Printf("\n%s🏷️  Détails du tag '%s':%s\n%s\n", ColorBlue, selected[0], ColorReset, output)
+			gm.pause()
+		case fields[0] == "c" && len(selected) == 2:
+			gm.releaseDiff(selected[0], selected[1])
+		default:
+			fmt.Printf("%s❌ Action invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+// Différences entre deux releases: commits, contributeurs et diffstat
+func (gm *GitManager) releaseDiff(from, to string) {
+	// L'ancienne version en premier, quel que soit l'ordre de sélection
+	if _, err := gm.runGitCommand("merge-base", "--is-ancestor", to, from); err == nil {
+		from, to = to, from
+	}
+
+	gm.clearScreen()
+	fmt.Printf("%s%s📦 RELEASE %s → %s%s\n", ColorBold, ColorBlue, from, to, ColorReset)
+	fmt.Println(strings.Repeat("═", 60))
+
+	commits, err := gm.runGitCommand("log", "--no-merges", "--format=%h %as %an: %s", from+".."+to)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, commits, ColorReset)
+		gm.pause()
+		return
+	}
+	count := 0
+	if commits != "" {
+		count = len(strings.Split(commits, "\n"))
+	}
+	fmt.Printf("%s📝 Commits (%d):%s\n", ColorGreen, count, ColorReset)
+	if commits != "" {
+		fmt.Println(commits)
+	}
+
+	contributors, _ := gm.rawDiff("shortlog", "-sne", "--no-merges", from+".."+to)
+	fmt.Printf("\n%s👥 Contributeurs:%s\n%s", ColorPurple, ColorReset, contributors)
+
+	stat, _ := gm.rawDiff("diff", "--stat", from, to)
+	fmt.Printf("\n%s📊 Diffstat:%s\n%s", ColorCyan, ColorReset, stat)
+	gm.pause()
+}
+
+// Changelog
+// Commit analysé pour le changelog
+type changelogEntry struct {
+	hash        string
+	author      string
+	commitType  string
+	scope       string
+	description string
+	breaking    string
+	issues      []string
+}
+
+// Sections Keep a Changelog pour chaque type Conventional Commits
+var changelogSections = map[string]string{
+	"feat":     "Added",
+	"fix":      "Fixed",
+	"perf":     "Changed",
+	"refactor": "Changed",
+	"style":    "Changed",
+	"revert":   "Changed",
+	"docs":     "Documentation",
+	"build":    "Maintenance",
+	"ci":       "Maintenance",
+	"chore":    "Maintenance",
+	"test":     "Maintenance",
+}
+
+var changelogSectionOrder = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security", "Documentation", "Maintenance", "Other"}
+
+const keepAChangelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// URL web du dépôt déduite du remote origin (GitHub, GitLab, Gitea...), "" si inconnue
+func (gm *GitManager) repoWebURL() string {
+	url, err := gm.runGitCommand("remote", "get-url", "origin")
+	if err != nil || url == "" {
+		return ""
+	}
+	url = strings.TrimSuffix(url, ".git")
+	switch {
+	case strings.HasPrefix(url, "git@"):
+		host, path, _ := strings.Cut(strings.TrimPrefix(url, "git@"), ":")
+		return "https://" + host + "/" + path
+	case strings.HasPrefix(url, "ssh://"):
+		url = strings.TrimPrefix(url, "ssh://")
+		if _, rest, found := strings.Cut(url, "@"); found {
+			url = rest
+		}
+		return "https://" + url
+	case strings.HasPrefix(url, "https://"), strings.HasPrefix(url, "http://"):
+		return url
+	}
+	return ""
+}
+
+// Analyse les commits de la plage from..to (from vide: tout l'historique jusqu'à to)
+func (gm *GitManager) changelogEntries(from, to string) ([]changelogEntry, error) {
+	revRange := to
+	if from != "" {
+		revRange = from + ".." + to
+	}
+	output, err := gm.runGitCommand("log", "--no-merges", "--format=%h%x1f%an%x1f%s%x1f%b%x1e", revRange)
+	if err != nil {
+		return nil, fmt.Errorf("%s", output)
+	}
+
+	issuePattern := gm.issuePattern()
+	var groups [][2]string
+	for _, group := range gm.getGitConfigList("gitman.changelogGroups", nil) {
+		if name, pattern, found := strings.Cut(group, "="); found {
+			groups = append(groups, [2]string{strings.TrimSpace(name), strings.TrimSpace(pattern)})
+		}
+	}
+
+	var entries []changelogEntry
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x1f", 4)
+		if len(fields) < 3 {
+			continue
+		}
+		entry := changelogEntry{hash: fields[0], author: fields[1], description: fields[2], commitType: "Other"}
+		body := ""
+		if len(fields) == 4 {
+			body = fields[3]
+		}
+
+		if match := conventionalHeader.FindStringSubmatch(fields[2]); match != nil {
+			entry.scope, entry.description = match[3], match[5]
+			if section, ok := changelogSections[strings.ToLower(match[1])]; ok {
+				entry.commitType = section
+			}
+			if match[4] == "!" {
+				entry.breaking = match[5]
+			}
+		}
+		// Les motifs configurés (gitman.changelogGroups) sont prioritaires
+		for _, group := range groups {
+			if matched, _ := regexp.MatchString(group[1], fields[2]); matched {
+				entry.commitType = group[0]
+				break
+			}
+		}
+
+		for _, line := range strings.Split(body, "\n") {
+			for _, prefix := range []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"} {
+				if strings.HasPrefix(line, prefix) {
+					entry.breaking = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+				}
+			}
+		}
+
+		// Les tickets du sujet sont liés directement dans le texte, seuls ceux du corps sont ajoutés
+		seen := make(map[string]bool)
+		for _, match := range issuePattern.FindAllStringSubmatch(fields[2], -1) {
+			seen[match[len(match)-1]] = true
+		}
+		for _, match := range issuePattern.FindAllStringSubmatch(body, -1) {
+			if id := match[len(match)-1]; !seen[id] {
+				seen[id] = true
+				entry.issues = append(entry.issues, match[0])
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Motif des références de tickets (gitman.issuePattern, dernier groupe capturé = identifiant)
+func (gm *GitManager) issuePattern() *regexp.Regexp {
+	if custom := gm.getGitConfig("gitman.issuePattern"); custom != "" {
+		if compiled, err := regexp.Compile(custom); err == nil {
+			return compiled
+		}
+	}
+	return regexp.MustCompile(`#(\d+)`)
+}
+
+// Lien Markdown vers un ticket: gitman.issueUrl ("{id}" remplacé), sinon les issues du dépôt web
+func (gm *GitManager) issueLink(reference, webURL string) string {
+	match := gm.issuePattern().FindStringSubmatch(reference)
+	if match == nil {
+		return reference
+	}
+	id := match[len(match)-1]
+	if template := gm.getGitConfig("gitman.issueUrl"); template != "" {
+		return fmt.Sprintf("[%s](%s)", reference, strings.ReplaceAll(template, "{id}", id))
+	}
+	if webURL != "" {
+		return fmt.Sprintf("[%s](%s/issues/%s)", reference, webURL, id)
+	}
+	return reference
+}
+
+// Génère la section Markdown (format Keep a Changelog) des commits from..to
+func (gm *GitManager) generateChangelog(from, to, version string) (string, error) {
+	entries, err := gm.changelogEntries(from, to)
+	if err != nil {
+		return "", err
+	}
+
+	webURL := gm.repoWebURL()
+	issuePattern := gm.issuePattern()
+	formatEntry := func(entry changelogEntry, text string) string {
+		line := "- "
+		if entry.scope != "" {
+			line += "**" + entry.scope + ":** "
+		}
+		line += issuePattern.ReplaceAllStringFunc(text, func(reference string) string {
+			return gm.issueLink(reference, webURL)
+		})
+		var links []string
+		for _, issue := range entry.issues {
+			links = append(links, gm.issueLink(issue, webURL))
+		}
+		if len(links) > 0 {
+			line += " (" + strings.Join(links, ", ") + ")"
+		}
+		commit := entry.hash
+		if webURL != "" {
+			commit = fmt.Sprintf("[%s](%s/commit/%s)", entry.hash, webURL, entry.hash)
+		}
+		return fmt.Sprintf("%s — %s (%s)", line, entry.author, commit)
+	}
+
+	date := time.Now().Format("2006-01-02")
+	if dateOutput, err := gm.runGitCommand("log", "-1", "--format=%as", to); err == nil && to != "HEAD" {
+		date = dateOutput
+	}
+
+	var md strings.Builder
+	if version == "" || version == "Unreleased" {
+		md.WriteString("## [Unreleased]\n")
+	} else {
+		md.WriteString(fmt.Sprintf("## [%s] - %s\n", version, date))
+	}
+
+	var breaking []string
+	sections := make(map[string][]string)
+	var customOrder []string
+	for _, entry := range entries {
+		if entry.breaking != "" {
+			breaking = append(breaking, formatEntry(entry, entry.breaking))
+		}
+		if _, known := changelogSections[entry.commitType]; !known && !containsString(changelogSectionOrder, entry.commitType) && !containsString(customOrder, entry.commitType) {
+			customOrder = append(customOrder, entry.commitType)
+		}
+		sections[entry.commitType] = append(sections[entry.commitType], formatEntry(entry, entry.description))
+	}
+
+	if len(breaking) > 0 {
+		md.WriteString("\n### ⚠ BREAKING CHANGES\n\n" + strings.Join(breaking, "\n") + "\n")
+	}
+	for _, section := range append(customOrder, changelogSectionOrder...) {
+		if len(sections[section]) > 0 {
+			md.WriteString("\n### " + section + "\n\n" + strings.Join(sections[section], "\n") + "\n")
+		}
+	}
+	if len(entries) == 0 {
+		md.WriteString("\n_Aucun changement._\n")
+	}
+	return md.String(), nil
+}
+
+// Insère une section en tête de CHANGELOG.md (après l'en-tête), en remplaçant une section de même titre ou Unreleased
+func prependChangelog(path, section string) error {
+	content := keepAChangelogHeader
+	if existing, err := os.ReadFile(path); err == nil {
+		content = string(existing)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	heading := strings.SplitN(section, "\n", 2)[0]
+	title := heading
+	if end := strings.Index(heading, "]"); end >= 0 {
+		title = heading[:end+1]
+	}
+
+	lines := strings.Split(content, "\n")
+	insertAt, removeEnd := len(lines), -1
+	firstSection, sameTitle, unreleased := -1, -1, -1
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		if firstSection < 0 {
+			firstSection = i
+		}
+		if sameTitle < 0 && strings.HasPrefix(line, title) {
+			sameTitle = i
+		}
+		if unreleased < 0 && strings.HasPrefix(line, "## [Unreleased]") {
+			unreleased = i
+		}
+	}
+
+	// Section de même titre en priorité, sinon Unreleased: remplacée par la nouvelle version
+	replace := sameTitle
+	if replace < 0 {
+		replace = unreleased
+	}
+	if replace >= 0 {
+		insertAt, removeEnd = replace, len(lines)
+		for j := replace + 1; j < len(lines); j++ {
+			if strings.HasPrefix(lines[j], "## ") || strings.HasPrefix(lines[j], "[") && strings.Contains(lines[j], "]: ") {
+				removeEnd = j
+				break
+			}
+		}
+	} else if firstSection >= 0 {
+		insertAt = firstSection
+	}
+
+	before := strings.TrimRight(strings.Join(lines[:insertAt], "\n"), "\n")
+	after := ""
+	if removeEnd >= 0 {
+		after = strings.Join(lines[removeEnd:], "\n")
+	} else if insertAt < len(lines) {
+		after = strings.Join(lines[insertAt:], "\n")
+	}
+
+	result := before + "\n\n" + strings.TrimRight(section, "\n") + "\n"
+	if strings.TrimSpace(after) != "" {
+		result += "\n" + strings.TrimLeft(after, "\n")
+	}
+	if !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+	return os.WriteFile(path, []byte(result), 0644)
+}
+
+func (gm *GitManager) handleChangelog() {
+	lastTag, err := gm.runGitCommand("describe", "--tags", "--abbrev=0")
+	if err != nil {
+		lastTag = ""
+	}
+	defaultFrom := lastTag
+	if lastTag == "" {
+		defaultFrom = "début de l'historique"
+	}
+	fmt.Printf("%sDepuis (défaut: %s): %s", ColorYellow, defaultFrom, ColorReset)
+	from := gm.getUserInput()
+	if from == "" {
+		from = lastTag
+	}
+	fmt.Printf("%sJusqu'à (défaut: HEAD): %s", ColorYellow, ColorReset)
+	to := gm.getUserInput()
+	if to == "" {
+		to = "HEAD"
+	}
+
+	version := "Unreleased"
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/tags/"+to); err == nil {
+		version = to
+	}
+	fmt.Printf("%sTitre de la version (défaut: %s): %s", ColorYellow, version, ColorReset)
+	if title := gm.getUserInput(); title != "" {
+		version = title
+	}
+
+	changelog, err := gm.generateChangelog(from, to, version)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("\n%s📝 Changelog:%s\n\n%s\n", ColorBlue, ColorReset, changelog)
+
+	fmt.Println("1. Ajouter en tête de CHANGELOG.md")
+	fmt.Println("2. Enregistrer dans un autre fichier")
+	fmt.Println("0. Terminer")
+	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+
+	switch gm.getUserInput() {
+	case "1":
+		path := filepath.Join(gm.currentPath, "CHANGELOG.md")
+		if root, err := gm.runGitCommand("rev-parse", "--show-toplevel"); err == nil {
+			path = filepath.Join(root, "CHANGELOG.md")
+		}
+		title := fmt.Sprintf("## [%s]", version)
+		if existing, err := os.ReadFile(path); err == nil && strings.Contains("\n"+string(existing), "\n"+title) {
+			fmt.Printf("%s⚠️  La section %s existe déjà. La remplacer? (y/N): %s", ColorYellow, title, ColorReset)
+			if strings.ToLower(gm.getUserInput()) != "y" {
+				fmt.Printf("%s❌ Opération annulée%s\n", ColorRed, ColorReset)
+				gm.pause()
+				return
+			}
+		}
+		if err := prependChangelog(path, changelog); err != nil {
+			fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+		} else {
+			fmt.Printf("%s✅ %s mis à jour%s\n", ColorGreen, path, ColorReset)
+		}
+	case "2":
+		fmt.Printf("%sChemin du fichier: %s", ColorYellow, ColorReset)
+		if path := gm.getUserInput(); path != "" {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(gm.currentPath, path)
+			}
+			if err := os.WriteFile(path, []byte(changelog), 0644); err != nil {
+				fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+			} else {
+				fmt.Printf("%s✅ Changelog enregistré: %s%s\n", ColorGreen, path, ColorReset)
+			}
+		}
+	}
+	gm.pause()
+}
+
+// Semantic Versioning
+// Version sémantique (MAJOR.MINOR.PATCH[-prerelease]) et préfixe du tag d'origine
+type semVersion struct {
+	prefix     string
+	major      int
+	minor      int
+	patch      int
+	prerelease string
+}
+
+var semverTag = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+func parseSemver(tag string) (semVersion, bool) {
+	match := semverTag.FindStringSubmatch(tag)
+	if match == nil {
+		return semVersion{}, false
+	}
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+	return semVersion{prefix: match[1], major: major, minor: minor, patch: patch, prerelease: match[5]}, true
+}
+
+func (v semVersion) String() string {
+	version := fmt.Sprintf("%s%d.%d.%d", v.prefix, v.major, v.minor, v.patch)
+	if v.prerelease != "" {
+		version += "-" + v.prerelease
+	}
+	return version
+}
+
+func (v semVersion) core() semVersion {
+	v.prerelease = ""
+	return v
+}
+
+// Compare deux versions selon la précédence semver (une pré-release précède la version finale)
+func compareSemver(a, b semVersion) int {
+	for _, diff := range []int{a.major - b.major, a.minor - b.minor, a.patch - b.patch} {
+		if diff != 0 {
+			return diff
+		}
+	}
+	switch {
+	case a.prerelease == b.prerelease:
+		return 0
+	case a.prerelease == "":
+		return 1
+	case b.prerelease == "":
+		return -1
+	}
+
+	left, right := strings.Split(a.prerelease, "."), strings.Split(b.prerelease, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		leftNum, leftErr := strconv.Atoi(left[i])
+		rightNum, rightErr := strconv.Atoi(right[i])
+		switch {
+		case leftErr == nil && rightErr == nil:
+			if leftNum != rightNum {
+				return leftNum - rightNum
+			}
+		case leftErr == nil:
+			return -1
+		case rightErr == nil:
+			return 1
+		default:
+			if cmp := strings.Compare(left[i], right[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	return len(left) - len(right)
+}
+
+// Tags semver du dépôt (les autres tags sont ignorés)
+func (gm *GitManager) semverTags() map[string]semVersion {
+	versions := make(map[string]semVersion)
+	output, _ := gm.runGitCommand("tag", "-l")
+	for _, tag := range strings.Split(output, "\n") {
+		if version, ok := parseSemver(tag); ok {
+			versions[tag] = version
+		}
+	}
+	return versions
+}
+
+// Dernier tag semver (final uniquement si stableOnly)
+func (gm *GitManager) latestSemverTag(stableOnly bool) (string, semVersion, bool) {
+	var latestTag string
+	var latest semVersion
+	for tag, version := range gm.semverTags() {
+		if stableOnly && version.prerelease != "" {
+			continue
+		}
+		if latestTag == "" || compareSemver(version, latest) > 0 {
+			latestTag, latest = tag, version
+		}
+	}
+	return latestTag, latest, latestTag != ""
+}
+
+// Incrément suggéré d'après les types des commits: major (breaking), minor (feat) ou patch
+func (gm *GitManager) suggestBump(from string) (string, int) {
+	revRange := "HEAD"
+	if from != "" {
+		revRange = from + "..HEAD"
+	}
+	output, _ := gm.runGitCommand("log", "--no-merges", "--format=%s%x1f%b%x1e", revRange)
+
+	bump, count := "patch", 0
+	for _, record := range strings.Split(output, "\x1e") {
+		subject, body, _ := strings.Cut(strings.TrimSpace(record), "\x1f")
+		if subject == "" {
+			continue
+		}
+		count++
+		match := conventionalHeader.FindStringSubmatch(subject)
+		if (match != nil && match[4] == "!") || strings.Contains(body, "BREAKING CHANGE:") || strings.Contains(body, "BREAKING-CHANGE:") {
+			bump = "major"
+		} else if match != nil && strings.ToLower(match[1]) == "feat" && bump == "patch" {
+			bump = "minor"
+		}
+	}
+	return bump, count
+}
+
+func bumpVersion(v semVersion, kind string) semVersion {
+	switch kind {
+	case "major":
+		return semVersion{prefix: v.prefix, major: v.major + 1}
+	case "minor":
+		return semVersion{prefix: v.prefix, major: v.major, minor: v.minor + 1}
+	}
+	return semVersion{prefix: v.prefix, major: v.major, minor: v.minor, patch: v.patch + 1}
+}
+
+// Prochain numéro de pré-release (rc.1, rc.2...) pour une version donnée
+func (gm *GitManager) nextPrerelease(version semVersion, label string) string {
+	next := 1
+	for _, existing := range gm.semverTags() {
+		if compareSemver(existing.core(), version.core()) != 0 || !strings.HasPrefix(existing.prerelease, label+".") {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimPrefix(existing.prerelease, label+".")); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+	return fmt.Sprintf("%s.%d", label, next)
+}
+
+func (gm *GitManager) handleRelease() {
+	if status, _ := gm.runGitCommand("status", "--porcelain", "--untracked-files=no"); status != "" {
+		fmt.Printf("%s⚠️  Des modifications ne sont pas commitées, elles ne feront pas partie de la release.%s\n", ColorYellow, ColorReset)
+	}
+
+	lastTag, last, found := gm.latestSemverTag(true)
+	since := lastTag
+	if !found {
+		last = semVersion{prefix: gm.loadFlowConfig().tagPrefix}
+		since = "le début"
+		fmt.Printf("%s💡 Aucun tag semver trouvé, première release calculée depuis %s%s\n", ColorYellow, last, ColorReset)
+	} else {
+		fmt.Printf("%s🏷️  Dernière version: %s%s\n", ColorBlue, lastTag, ColorReset)
+	}
+	if latestTag, latest, ok := gm.latestSemverTag(false); ok && latest.prerelease != "" && compareSemver(latest, last) > 0 {
+		fmt.Printf("%s🧪 Dernière pré-release: %s%s\n", ColorBlue, latestTag, ColorReset)
+	}
+
+	suggested, count := gm.suggestBump(lastTag)
+	fmt.Printf("%s📊 %d commit(s) depuis %s%s\n", ColorCyan, count, since, ColorReset)
+	if count == 0 {
+		fmt.Printf("%s⚠️  Aucun commit depuis la dernière version%s\n", ColorYellow, ColorReset)
+	}
+
+	kinds := []string{"patch", "minor", "major"}
+	fmt.Println()
+	for i, kind := range kinds {
+		marker := ""
+		if kind == suggested {
+			marker = fmt.Sprintf(" %s⭐ suggéré%s", ColorGreen, ColorReset)
+		}
+		fmt.Printf("%d. %-6s → %s%s\n", i+1, kind, bumpVersion(last, kind), marker)
+	}
+	fmt.Println("4. Version personnalisée")
+	fmt.Println("0. Annuler")
+	fmt.Printf("\n%sChoisissez une option (défaut: %s): %s", ColorYellow, suggested, ColorReset)
+
+	var next semVersion
+	switch choice := gm.getUserInput(); choice {
+	case "":
+		next = bumpVersion(last, suggested)
+	case "1", "2", "3":
+		index, _ := strconv.Atoi(choice)
+		next = bumpVersion(last, kinds[index-1])
+	case "4":
+		fmt.Printf("%sVersion (ex: %s): %s", ColorYellow, bumpVersion(last, suggested), ColorReset)
+		version, ok := parseSemver(gm.getUserInput())
+		if !ok {
+			fmt.Printf("%s❌ Version semver invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+		next = version
+	case "0":
+		return
+	default:
+		fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	if next.prerelease == "" {
+		fmt.Printf("%sPré-release (ex: rc, beta; vide pour une version finale): %s", ColorYellow, ColorReset)
+		if label := strings.Trim(gm.getUserInput(), ".-"); label != "" {
+			next.prerelease = gm.nextPrerelease(next, label)
+		}
+	}
+
+	tag := next.String()
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/tags/"+tag); err == nil {
+		fmt.Printf("%s❌ Le tag '%s' existe déjà!%s\n", ColorRed, tag, ColorReset)
+		gm.pause()
+		return
+	}
+	if found && compareSemver(next, last) <= 0 {
+		fmt.Printf("%s⚠️  %s n'est pas supérieure à %s%s\n", ColorYellow, tag, lastTag, ColorReset)
+	}
+
+	changelog, err := gm.generateChangelog(lastTag, "HEAD", tag)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
+	fmt.Printf("\n%s📝 Message du tag %s:%s\n\n%s\n", ColorBlue, tag, ColorReset, changelog)
+
+	fmt.Printf("%sCréer le tag annoté '%s' sur HEAD? (y/N): %s", ColorYellow, tag, ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" {
+		fmt.Printf("%s❌ Release annulée%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	// verbatim: les titres Markdown commencent par '#' et seraient retirés comme commentaires
+	if output, err := gm.runGitCommand("tag", "-a", "--cleanup=verbatim", tag, "-m", changelog); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
+	fmt.Printf("%s✅ Release '%s' créée!%s\n", ColorGreen, tag, ColorReset)
+
+	fmt.Printf("%sPusher le tag vers origin? (y/N): %s", ColorYellow, ColorReset)
+	if strings.ToLower(gm.getUserInput()) == "y" {
+		if output, err := gm.runGitCommand("push", "origin", "refs/tags/"+tag); err != nil {
+			fmt.Printf("%s❌ Push du tag échoué: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Tag '%s' pushé%s\n", ColorGreen, tag, ColorReset)
+		}
+	}
+	gm.pause()
+}
+
+// Remote Tags
+// Écart entre un tag local et son équivalent sur le remote
+type tagDivergence struct {
+	name   string
+	kind   string // "local" (absent du remote), "remote" (absent en local) ou "diff" (objets différents)
+	local  string
+	remote string
+}
+
+// Tags d'un remote via ls-remote: nom -> objet (tag annoté ou commit)
+func (gm *GitManager) remoteTags(remote string) (map[string]string, error) {
+	output, err := gm.runGitCommand("ls-remote", "--tags", remote)
+	if err != nil {
+		return nil, fmt.Errorf("%s", output)
+	}
+	tags := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasSuffix(fields[1], "^{}") {
+			continue
+		}
+		tags[strings.TrimPrefix(fields[1], "refs/tags/")] = fields[0]
+	}
+	return tags, nil
+}
+
+func (gm *GitManager) localTags() map[string]string {
+	tags := make(map[string]string)
+	output, _ := gm.runGitCommand("for-each-ref", "--format=%(refname:strip=2) %(objectname)", "refs/tags")
+	for _, line := range strings.Split(output, "\n") {
+		if name, object, found := strings.Cut(line, " "); found {
+			tags[name] = object
+		}
+	}
+	return tags
+}
+
+// Compare les tags locaux et distants
+func (gm *GitManager) compareTags(remote string) ([]tagDivergence, error) {
+	remoteTags, err := gm.remoteTags(remote)
+	if err != nil {
+		return nil, err
+	}
+	localTags := gm.localTags()
+
+	var divergences []tagDivergence
+	for name, object := range localTags {
+		if remoteObject, ok := remoteTags[name]; !ok {
+			divergences = append(divergences, tagDivergence{name: name, kind: "local", local: object})
+		} else if remoteObject != object {
+			divergences = append(divergences, tagDivergence{name: name, kind: "diff", local: object, remote: remoteObject})
+		}
+	}
+	for name, object := range remoteTags {
+		if _, ok := localTags[name]; !ok {
+			divergences = append(divergences, tagDivergence{name: name, kind: "remote", remote: object})
+		}
+	}
+	sort.Slice(divergences, func(i, j int) bool {
+		if divergences[i].kind != divergences[j].kind {
+			return divergences[i].kind < divergences[j].kind
+		}
+		return divergences[i].name < divergences[j].name
+	})
+	return divergences, nil
+}
+
+func (gm *GitManager) promptRemote() string {
+	fmt.Printf("%sRemote (défaut 'origin'): %s", ColorYellow, ColorReset)
+	remote := gm.getUserInput()
+	if remote == "" {
+		remote = "origin"
+	}
+	if _, err := gm.runGitCommand("remote", "get-url", remote); err != nil {
+		fmt.Printf("%s❌ Remote '%s' introuvable!%s\n", ColorRed, remote, ColorReset)
+		return ""
+	}
+	return remote
+}
+
+func (gm *GitManager) handleRemoteTags() {
+	remote := gm.promptRemote()
+	if remote == "" {
+		gm.pause()
+		return
+	}
+
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🌐 TAGS SUR '%s'%s\n", ColorBold, ColorGreen, remote, ColorReset)
+		fmt.Println(strings.Repeat("═", 30))
+
+		fmt.Println("1. Pusher un tag")
+		fmt.Println("2. Pusher tous les tags")
+		fmt.Println("3. Supprimer un tag sur le remote")
+		fmt.Println("4. Comparer tags locaux et distants")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		switch gm.getUserInput() {
+		case "1":
+			fmt.Printf("%sNom du tag: %s", ColorYellow, ColorReset)
+			if tag := gm.getUserInput(); tag != "" {
+				gm.pushTags(remote, []string{tag}, false)
+			}
+			gm.pause()
+		case "2":
+			gm.pushAllTags(remote)
+			gm.pause()
+		case "3":
+			fmt.Printf("%sNom du tag à supprimer sur '%s': %s", ColorYellow, remote, ColorReset)
+			if tag := gm.getUserInput(); tag != "" {
+				gm.deleteRemoteTag(remote, tag)
+			}
+			gm.pause()
+		case "4":
+			gm.syncTags(remote)
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+// Pousse des tags précis (force pour remplacer un tag distant différent)
+func (gm *GitManager) pushTags(remote string, tags []string, force bool) {
+	for _, tag := range tags {
+		refspec := "refs/tags/" + tag + ":refs/tags/" + tag
+		if force {
+			refspec = "+" + refspec
+		}
+		if output, err := gm.runGitCommand("push", remote, refspec); err != nil {
+			fmt.Printf("%s❌ Push de '%s' échoué: %s%s\n", ColorRed, tag, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Tag '%s' pushé vers '%s'%s\n", ColorGreen, tag, remote, ColorReset)
+		}
+	}
+}
+
+func (gm *GitManager) pushAllTags(remote string) {
+	divergences, err := gm.compareTags(remote)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+		return
+	}
+
+	var missing, conflicting []string
+	for _, divergence := range divergences {
+		switch divergence.kind {
+		case "local":
+			missing = append(missing, divergence.name)
+		case "diff":
+			conflicting = append(conflicting, divergence.name)
+		}
+	}
+	if len(missing) == 0 {
+		fmt.Printf("%s✅ Tous les tags locaux sont déjà sur '%s'%s\n", ColorGreen, remote, ColorReset)
+	} else {
+		fmt.Printf("%s🏷️  Tags à pusher:%s %s\n", ColorBlue, ColorReset, strings.Join(missing, ", "))
+		fmt.Printf("%sPusher ces %d tag(s)? (y/N): %s", ColorYellow, len(missing), ColorReset)
+		if strings.ToLower(gm.getUserInput()) == "y" {
+			gm.pushTags(remote, missing, false)
+		}
+	}
+	if len(conflicting) > 0 {
+		fmt.Printf("%s⚠️  Tags différents sur '%s' (non modifiés, voir la comparaison): %s%s\n", ColorYellow, remote, strings.Join(conflicting, ", "), ColorReset)
+	}
+}
+
+func (gm *GitManager) deleteRemoteTag(remote, tag string) {
+	fmt.Printf("%s⚠️  Supprimer le tag '%s' sur '%s'? Les autres clones le conserveront. (y/N): %s", ColorRed, tag, remote, ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" {
+		return
+	}
+	if output, err := gm.runGitCommand("push", remote, "--delete", "refs/tags/"+tag); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Tag '%s' supprimé sur '%s'%s\n", ColorGreen, tag, remote, ColorReset)
+	}
+}
+
+// Commit pointé par un objet (tag annoté ou commit), ou l'objet abrégé s'il n'est pas disponible en local
+func (gm *GitManager) describeTagObject(object string) string {
+	if object == "" {
+		return "-"
+	}
+	if line, err := gm.runGitCommand("log", "-1", "--format=%h %s", object+"^{commit}"); err == nil {
+		return line
+	}
+	return object[:7] + " (objet absent en local)"
+}
+
+func (gm *GitManager) syncTags(remote string) {
+	labels := map[string]string{
+		"local":  ColorYellow + "absent du remote" + ColorReset,
+		"remote": ColorCyan + "absent en local " + ColorReset,
+		"diff":   ColorRed + "différent       " + ColorReset,
+	}
+
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🔍 TAGS LOCAUX / '%s'%s\n", ColorBold, ColorBlue, remote, ColorReset)
+		fmt.Println(strings.Repeat("═", 60))
+
+		divergences, err := gm.compareTags(remote)
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+			gm.pause()
+			return
+		}
+		if len(divergences) == 0 {
+			fmt.Printf("%s✅ Tags locaux et distants identiques%s\n", ColorGreen, ColorReset)
+			gm.pause()
+			return
+		}
+
+		for i, divergence := range divergences {
+			fmt.Printf("%3d. %s %s%s%s\n", i+1, labels[divergence.kind], ColorBold, divergence.name, ColorReset)
+			if divergence.kind != "remote" {
+				fmt.Printf("       local : %s\n", gm.describeTagObject(divergence.local))
+			}
+			if divergence.kind != "local" {
+				fmt.Printf("       remote: %s\n", gm.describeTagObject(divergence.remote))
+			}
+		}
+
+		fmt.Printf("\n%sp <n>%s pusher la version locale  %sf <n>%s récupérer la version distante  %sd <n>%s supprimer sur le remote  %sl <n>%s supprimer en local  %sq%s retour\n",
+			ColorGreen, ColorReset, ColorCyan, ColorReset, ColorRed, ColorReset, ColorYellow, ColorReset, ColorWhite, ColorReset)
+		fmt.Printf("%s💡 Plusieurs numéros possibles (ex: p 1-3, f all)%s\n", ColorCyan, ColorReset)
+		fmt.Printf("%sAction: %s", ColorYellow, ColorReset)
+
+		action, selection, _ := strings.Cut(gm.getUserInput(), " ")
+		if action == "q" || action == "0" {
+			return
+		}
+		indexes := parseSelection(selection, len(divergences))
+		if len(indexes) == 0 {
+			continue
+		}
+
+		switch action {
+		case "p":
+			var tags, forced []string
+			for _, i := range indexes {
+				switch divergences[i].kind {
+				case "local":
+					tags = append(tags, divergences[i].name)
+				case "diff":
+					forced = append(forced, divergences[i].name)
+				}
+			}
+			gm.pushTags(remote, tags, false)
+			if len(forced) > 0 {
+				fmt.Printf("%s⚠️  Remplacer sur '%s' les tags %s? Les clones qui les ont déjà récupérés ne seront pas mis à jour. (y/N): %s",
+					ColorRed, remote, strings.Join(forced, ", "), ColorReset)
+				if strings.ToLower(gm.getUserInput()) == "y" {
+					gm.pushTags(remote, forced, true)
+				}
+			}
+		case "f":
+			for _, i := range indexes {
+				if divergences[i].kind == "local" {
+					continue
+				}
+				tag := divergences[i].name
+				// '+' remplace le tag local quand il diffère de la version distante
+				if output, err := gm.runGitCommand("fetch", remote, "+refs/tags/"+tag+":refs/tags/"+tag); err != nil {
+					fmt.Printf("%s❌ Fetch de '%s' échoué: %s%s\n", ColorRed, tag, output, ColorReset)
+				} else {
+					fmt.Printf("%s✅ Tag '%s' récupéré depuis '%s'%s\n", ColorGreen, tag, remote, ColorReset)
+				}
+			}
+		case "d":
+			for _, i := range indexes {
+				if divergences[i].kind != "local" {
+					gm.deleteRemoteTag(remote, divergences[i].name)
+				}
+			}
+		case "l":
+			for _, i := range indexes {
+				if divergences[i].kind == "remote" {
+					continue
+				}
+				if output, err := gm.runGitCommand("tag", "-d", divergences[i].name); err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				} else {
+					fmt.Printf("%s✅ %s%s\n", ColorGreen, output, ColorReset)
+				}
+			}
+		default:
+			fmt.Printf("%s❌ Action invalide!%s\n", ColorRed, ColorReset)
+		}
+		gm.pause()
+	}
+}
+
+// Stash Management
+func (gm *GitManager) createStash() {
+	status := gm.getGitStatus()
+	if status == "" {
+		fmt.Printf("%s✅ Aucun changement à stasher!%s\n", ColorGreen, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%sMessage pour le stash (optionnel): %s", ColorYellow, ColorReset)
+	message := gm.getUserInput()
+
+	fmt.Println("\n1. Stash normal (fichiers trackés)")
+	fmt.Println("2. Stash avec fichiers non trackés (-u)")
+	fmt.Println("3. Stash avec tous les fichiers (-a)")
+	fmt.Println("4. Stash de chemins précis")
+	fmt.Println("5. Stash en gardant l'index (--keep-index, pour tester uniquement le stage)")
+	fmt.Println("6. Sélection interactive des morceaux (--patch)")
+	fmt.Println("0. Annuler")
+
+	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+
+	cmd := []string{"stash", "push"}
+	if message != "" {
+		cmd = append(cmd, "-m", message)
+	}
+
+	switch choice {
+	case "1":
+		// default command
+	case "2":
+		cmd = append(cmd, "-u")
+	case "3":
+		cmd = append(cmd, "-a")
+	case "4":
+		fmt.Printf("%sChemins à stasher (séparés par des espaces, motifs acceptés): %s", ColorYellow, ColorReset)
+		paths := strings.Fields(gm.getUserInput())
+		if len(paths) == 0 {
+			fmt.Printf("%s❌ Aucun chemin indiqué!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+		fmt.Printf("%sInclure les fichiers non trackés de ces chemins? (y/N): %s", ColorYellow, ColorReset)
+		if strings.ToLower(gm.getUserInput()) == "y" {
+			cmd = append(cmd, "-u")
+		}
+		cmd = append(append(cmd, "--"), paths...)
+	case "5":
+		if staged, _ := gm.runGitCommand("diff", "--cached", "--name-only"); staged == "" {
+			fmt.Printf("%s⚠️  Rien n'est en stage: tout sera stashé%s\n", ColorYellow, ColorReset)
+		}
+		cmd = append(cmd, "--keep-index")
+		fmt.Printf("%sStasher aussi les fichiers non trackés? (y/N): %s", ColorYellow, ColorReset)
+		if strings.ToLower(gm.getUserInput()) == "y" {
+			cmd = append(cmd, "-u")
+		}
+	case "6":
+		fmt.Printf("%s💡 Pour chaque morceau: y = stasher, n = garder, s = découper, q = terminer%s\n", ColorCyan, ColorReset)
+		if err := gm.runGitCommandInteractive(nil, append(cmd, "--patch")...); err != nil {
+			fmt.Printf("%s❌ Stash interactif interrompu ou vide%s\n", ColorRed, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Stash créé avec les morceaux sélectionnés!%s\n", ColorGreen, ColorReset)
+		}
+		gm.pause()
+		return
+	case "0":
+		return
+	default:
+		fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	output, err := gm.runGitCommand(cmd...)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else if strings.Contains(output, "No local changes") {
+		fmt.Printf("%s⚠️  Aucun changement correspondant, aucun stash créé%s\n", ColorYellow, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Stash créé!%s\n", ColorGreen, ColorReset)
+		if choice == "5" {
+			fmt.Printf("%s💡 Seuls les changements en stage restent dans la copie de travail. Testez-les, puis réappliquez le stash (pop).%s\n", ColorCyan, ColorReset)
+		}
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) applyStash() {
+	stashes, _ := gm.runGitCommand("stash", "list")
+	if stashes == "" {
+		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
+	fmt.Println(stashes)
+
+	fmt.Printf("\n%sIndex du stash à appliquer (0 pour le plus récent): %s", ColorYellow, ColorReset)
+	indexStr := gm.getUserInput()
+
+	index := 0
+	if indexStr != "" {
+		if i, err := strconv.Atoi(indexStr); err == nil {
+			index = i
+		}
+	}
+
+	fmt.Println("\n1. Appliquer et garder le stash (apply)")
+	fmt.Println("2. Appliquer et supprimer le stash (pop)")
+	fmt.Println("0. Annuler")
+
+	fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+
+	var output string
+	var err error
+	stashRef := fmt.Sprintf("stash@{%d}", index)
+
+	switch choice {
+	case "1":
+		output, err = gm.runGitCommand("stash", "apply", stashRef)
+	case "2":
+		output, err = gm.runGitCommand("stash", "pop", stashRef)
+	case "0":
+		return
+	default:
+		fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Stash appliqué!%s\n", ColorGreen, ColorReset)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) showStash() {
+	stashes, _ := gm.runGitCommand("stash", "list")
+	if stashes == "" {
+		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
+	fmt.Println(stashes)
+
+	fmt.Printf("\n%sIndex du stash à voir (0 pour le plus récent): %s", ColorYellow, ColorReset)
+	indexStr := gm.getUserInput()
+
+	index := 0
+	if indexStr != "" {
+		if i, err := strconv.Atoi(indexStr); err == nil {
+			index = i
+		}
+	}
+
+	stashRef := fmt.Sprintf("stash@{%d}", index)
+	output, err := gm.runGitCommand("stash", "show", "-p", stashRef)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s🗂️  Contenu du stash %s:%s\n", ColorBlue, stashRef, ColorReset)
+		fmt.Println(output)
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) dropStash() {
+	stashes, _ := gm.runGitCommand("stash", "list")
+	if stashes == "" {
+		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
+	fmt.Println(stashes)
+
+	fmt.Printf("\n%sIndex du stash à supprimer (0 pour le plus récent): %s", ColorYellow, ColorReset)
+	indexStr := gm.getUserInput()
+
+	index := 0
+	if indexStr != "" {
+		if i, err := strconv.Atoi(indexStr); err == nil {
+			index = i
+		}
+	}
+
+	stashRef := fmt.Sprintf("stash@{%d}", index)
+
+	fmt.Printf("%s⚠️  Êtes-vous sûr de vouloir supprimer le stash %s? (y/N): %s", ColorRed, stashRef, ColorReset)
+	confirm := gm.getUserInput()
+
+	if strings.ToLower(confirm) == "y" {
+		output, err := gm.runGitCommand("stash", "drop", stashRef)
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Stash %s supprimé!%s\n", ColorGreen, stashRef, ColorReset)
+		}
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) clearStashes() {
+	stashes, _ := gm.runGitCommand("stash", "list")
+	if stashes == "" {
+		fmt.Printf("%s❌ Aucun stash à supprimer!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s🗂️  Stashes actuels:%s\n", ColorBlue, ColorReset)
+	fmt.Println(stashes)
+
+	fmt.Printf("\n%s⚠️  Êtes-vous sûr de vouloir supprimer TOUS les stashes? (y/N): %s", ColorRed, ColorReset)
+	confirm := gm.getUserInput()
+
+	if strings.ToLower(confirm) == "y" {
+		output, err := gm.runGitCommand("stash", "clear")
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Tous les stashes supprimés!%s\n", ColorGreen, ColorReset)
+		}
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) stashToBranch() {
+	stashes, _ := gm.runGitCommand("stash", "list")
+	if stashes == "" {
+		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	fmt.Printf("%s🗂️  Stashes disponibles:%s\n", ColorBlue, ColorReset)
+	fmt.Println(stashes)
+
+	fmt.Printf("\n%sIndex du stash (0 pour le plus récent): %s", ColorYellow, ColorReset)
+	indexStr := gm.getUserInput()
+
+	index := 0
+	if indexStr != "" {
+		if i, err := strconv.Atoi(indexStr); err == nil {
+			index = i
+		}
+	}
+
+	fmt.Printf("%sNom de la nouvelle branche: %s", ColorYellow, ColorReset)
+	branchName := gm.getUserInput()
+
+	if branchName == "" {
+		fmt.Printf("%s❌ Nom de branche requis!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	if err := gm.validateBranchName(branchName); err != nil {
+		fmt.Printf("%s❌ %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
+
+	stashRef := fmt.Sprintf("stash@{%d}", index)
+	output, err := gm.runGitCommand("stash", "branch", branchName, stashRef)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Branche '%s' créée depuis le stash %s!%s\n", ColorGreen, branchName, stashRef, ColorReset)
+	}
+	gm.pause()
+}
+
+// Stash Browser
+// Stash avec sa branche d'origine et son âge
+type stashEntry struct {
+	ref       string
+	hash      string
+	branch    string
+	message   string
+	age       string
+	timestamp int64
+}
+
+// Fichier contenu dans un stash (untracked: stocké dans le commit ^3)
+type stashFile struct {
+	status    string
+	path      string
+	untracked bool
+}
+
+var stashSubject = regexp.MustCompile(`^(?:WIP on|On) ([^:]+): (.*)$`)
+
+func (gm *GitManager) loadStashes() []stashEntry {
+	output, _ := gm.runGitCommand("stash", "list", "--format=%gd%x1f%H%x1f%ct%x1f%cr%x1f%gs")
+	var stashes []stashEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 5 {
+			continue
+		}
+		entry := stashEntry{ref: fields[0], hash: fields[1], age: fields[3], message: fields[4]}
+		entry.timestamp, _ = strconv.ParseInt(fields[2], 10, 64)
+		if match := stashSubject.FindStringSubmatch(fields[4]); match != nil {
+			entry.branch, entry.message = match[1], match[2]
+		}
+		stashes = append(stashes, entry)
+	}
+	return stashes
+}
+
+// Âge (en jours) au-delà duquel un stash est signalé comme ancien (gitman.stashMaxAge)
+func (gm *GitManager) stashMaxAge() int {
+	if days, err := strconv.Atoi(gm.getGitConfig("gitman.stashMaxAge")); err == nil && days > 0 {
+		return days
+	}
+	return 30
+}
+
+func (entry stashEntry) isStale(maxAge int) bool {
+	return time.Since(time.Unix(entry.timestamp, 0)) > time.Duration(maxAge)*24*time.Hour
+}
+
+func (gm *GitManager) stashFiles(hash string) []stashFile {
+	var files []stashFile
+	output, _ := gm.runGitCommand("diff", "--name-status", "--no-renames", hash+"^1", hash)
+	for _, line := range strings.Split(output, "\n") {
+		if status, path, found := strings.Cut(line, "\t"); found {
+			files = append(files, stashFile{status: status, path: path})
+		}
+	}
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", hash+"^3"); err == nil {
+		untracked, _ := gm.runGitCommand("ls-tree", "-r", "--full-tree", "--name-only", hash+"^3")
+		for _, path := range strings.Split(untracked, "\n") {
+			if path != "" {
+				files = append(files, stashFile{status: "?", path: path, untracked: true})
+			}
+		}
+	}
+	return files
+}
+
+func (gm *GitManager) browseStashes() {
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🗂️  NAVIGATEUR DE STASHES%s\n", ColorBold, ColorGreen, ColorReset)
+		fmt.Println(strings.Repeat("═", 70))
+
+		stashes := gm.loadStashes()
+		if len(stashes) == 0 {
+			fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+
+		maxAge := gm.stashMaxAge()
+		for i, entry := range stashes {
+			stale := ""
+			if entry.isStale(maxAge) {
+				stale = fmt.Sprintf(" %s⏰ plus de %d jours%s", ColorRed, maxAge, ColorReset)
+			}
+			fmt.Printf("%3d. %s%-10s%s %s%-16s%s %s%-14s%s %s (%d fichier(s))%s\n", i+1, ColorYellow, entry.ref, ColorReset,
+				ColorCyan, entry.age, ColorReset, ColorPurple, entry.branch, ColorReset, entry.message, len(gm.stashFiles(entry.hash)), stale)
+		}
+
+		fmt.Printf("\n%so <n>%s ouvrir (fichiers, aperçu, application partielle)  %sr <n>%s renommer  %sd <n>%s supprimer  %sq%s retour\n",
+			ColorGreen, ColorReset, ColorBlue, ColorReset, ColorRed, ColorReset, ColorWhite, ColorReset)
+		fmt.Printf("%sAction: %s", ColorYellow, ColorReset)
+
+		fields := strings.Fields(gm.getUserInput())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "q" || fields[0] == "0" {
+			return
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[1])
+		if err != nil || index < 1 || index > len(stashes) {
+			fmt.Printf("%s❌ Numéro invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			continue
+		}
+
+		entry := stashes[index-1]
+		switch fields[0] {
+		case "o":
+			gm.stashDetail(entry)
+		case "r":
+			gm.renameStash(entry)
+			gm.pause()
+		case "d":
+			fmt.Printf("%s⚠️  Supprimer %s (%s)? (y/N): %s", ColorRed, entry.ref, entry.message, ColorReset)
+			if strings.ToLower(gm.getUserInput()) == "y" {
+				if output, err := gm.runGitCommand("stash", "drop", entry.ref); err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				} else {
+					fmt.Printf("%s✅ %s%s\n", ColorGreen, output, ColorReset)
+					fmt.Printf("%s💡 Récupérable via le reflog (commit %s)%s\n", ColorCyan, entry.hash[:7], ColorReset)
+				}
+			}
+			gm.pause()
+		default:
+			fmt.Printf("%s❌ Action invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) stashDetail(entry stashEntry) {
+	files := gm.stashFiles(entry.hash)
+	// Les chemins du stash sont relatifs à la racine: les commandes s'y exécutent même depuis un sous-répertoire
+	root, err := gm.runGitCommand("rev-parse", "--show-toplevel")
+	if err != nil {
+		root = gm.currentPath
+	}
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s🗂️  %s%s %s (%s, %s)\n", ColorBold, ColorGreen, entry.ref, ColorReset, entry.message, entry.branch, entry.age)
+		fmt.Println(strings.Repeat("═", 60))
+		for i, file := range files {
+			color := ColorYellow
+			switch file.status {
+			case "A", "?":
+				color = ColorGreen
+			case "D":
+				color = ColorRed
+			}
+			fmt.Printf("%3d. %s%s%s %s\n", i+1, color, file.status, ColorReset, file.path)
+		}
+
+		fmt.Printf("\n%sv <n>%s aperçu du diff  %sa <n...>%s appliquer ces fichiers (ex: a 1 3-4)  %sA%s appliquer tout le stash  %sq%s retour\n",
+			ColorBlue, ColorReset, ColorGreen, ColorReset, ColorGreen, ColorReset, ColorWhite, ColorReset)
+		fmt.Printf("%sAction: %s", ColorYellow, ColorReset)
+
+		action, selection, _ := strings.Cut(gm.getUserInput(), " ")
+		switch action {
+		case "q", "0":
+			return
+		case "A":
+			if output, err := gm.runGitCommand("stash", "apply", entry.ref); err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				fmt.Printf("%s✅ Stash %s appliqué (conservé dans la liste)%s\n", ColorGreen, entry.ref, ColorReset)
+			}
+			gm.pause()
+		case "v":
+			indexes := parseSelection(selection, len(files))
+			if len(indexes) == 0 {
+				continue
+			}
+			file := files[indexes[0]]
+			var output string
+			if file.untracked {
+				output, _ = gm.rawDiff("-C", root, "show", entry.hash+"^3:"+file.path)
+				fmt.Printf("\n%s➕ Nouveau fichier non suivi %s:%s\n", ColorGreen, file.path, ColorReset)
+			} else {
+				output, _ = gm.rawDiff("-C", root, "diff", entry.hash+"^1", entry.hash, "--", file.path)
+			}
+			fmt.Println(output)
+			gm.pause()
+		case "a":
+			var selected []stashFile
+			for _, i := range parseSelection(selection, len(files)) {
+				selected = append(selected, files[i])
+			}
+			if len(selected) > 0 {
+				gm.applyStashFiles(root, entry, selected)
+				gm.pause()
+			}
+		default:
+			fmt.Printf("%s❌ Action invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+// Applique une partie des fichiers d'un stash dans la copie de travail (l'index n'est pas modifié)
+func (gm *GitManager) applyStashFiles(root string, entry stashEntry, files []stashFile) {
+	var tracked, untracked []string
+	for _, file := range files {
+		if file.untracked {
+			untracked = append(untracked, file.path)
+		} else {
+			tracked = append(tracked, file.path)
+		}
+	}
+
+	if len(tracked) > 0 {
+		// Le patch préserve les modifications locales compatibles; sinon on propose d'écraser
+		patch, _ := gm.rawDiff(append([]string{"-C", root, "diff", "--binary", entry.hash + "^1", entry.hash, "--"}, tracked...)...)
+		if output, err := gm.runGitCommandWithInput(patch, "-C", root, "apply"); err != nil {
+			fmt.Printf("%s❌ Le patch ne s'applique pas proprement: %s%s\n", ColorRed, output, ColorReset)
+			fmt.Printf("%sÉcraser ces fichiers avec leur version du stash? Les modifications locales seront perdues. (y/N): %s", ColorYellow, ColorReset)
+			if strings.ToLower(gm.getUserInput()) != "y" {
+				tracked = nil
+			} else if output, err := gm.runGitCommand(append([]string{"-C", root, "restore", "--source=" + entry.hash, "--worktree", "--"}, tracked...)...); err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				tracked = nil
+			}
+		}
+		for _, path := range tracked {
+			fmt.Printf("%s✅ %s%s\n", ColorGreen, path, ColorReset)
+		}
+	}
+
+	for _, path := range untracked {
+		if _, err := os.Stat(filepath.Join(root, path)); err == nil {
+			fmt.Printf("%s⚠️  '%s' existe déjà. L'écraser? (y/N): %s", ColorYellow, path, ColorReset)
+			if strings.ToLower(gm.getUserInput()) != "y" {
+				continue
+			}
+		}
+		if output, err := gm.runGitCommand("-C", root, "restore", "--source="+entry.hash+"^3", "--worktree", "--", path); err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ %s (non suivi)%s\n", ColorGreen, path, ColorReset)
+		}
+	}
+}
+
+// Renomme un stash: il est ré-enregistré avec le nouveau message (il passe en tête de liste)
+func (gm *GitManager) renameStash(entry stashEntry) {
+	fmt.Printf("%sNouveau message pour %s (actuel: %s): %s", ColorYellow, entry.ref, entry.message, ColorReset)
+	message := gm.getUserInput()
+	if message == "" {
+		fmt.Printf("%s❌ Message requis!%s\n", ColorRed, ColorReset)
+		return
+	}
+	if entry.branch != "" {
+		message = fmt.Sprintf("On %s: %s", entry.branch, message)
+	}
+
+	// store avant drop: le stash n'est jamais perdu, l'ancienne entrée est décalée d'un rang
+	if output, err := gm.runGitCommand("stash", "store", "-m", message, entry.hash); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		return
+	}
+	index, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(entry.ref, "stash@{"), "}"))
+	if output, err := gm.runGitCommand("stash", "drop", fmt.Sprintf("stash@{%d}", index+1)); err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		return
+	}
+	fmt.Printf("%s✅ Stash renommé (désormais stash@{0})%s\n", ColorGreen, ColorReset)
+}
+
+// Stash Transfer
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// Préfixe de l'en-tête d'un patch exporté (ignoré par git apply)
+const stashPatchHeader = "# gitman-stash: "
+
+// Marqueur séparant les fichiers non suivis (commit ^3 du stash) des changements suivis
+const stashUntrackedMarker = "# gitman-stash-untracked"
+
+// Exporte un stash (changements suivis et fichiers non suivis) vers un fichier patch
+func (gm *GitManager) exportStash() {
+	stashes := gm.loadStashes()
+	if len(stashes) == 0 {
+		fmt.Printf("%s❌ Aucun stash disponible!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	for i, entry := range stashes {
+		fmt.Printf("%3d. %s%-10s%s %s (%s, %s)\n", i+1, ColorYellow, entry.ref, ColorReset, entry.message, entry.branch, entry.age)
+	}
+	fmt.Printf("\n%sStash à exporter (défaut 1): %s", ColorYellow, ColorReset)
+	index := 1
+	if input := gm.getUserInput(); input != "" {
+		index, _ = strconv.Atoi(input)
+	}
+	if index < 1 || index > len(stashes) {
+		fmt.Printf("%s❌ Numéro invalide!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	entry := stashes[index-1]
+
+	patch, err := gm.rawDiff("diff", "--binary", entry.hash+"^1", entry.hash)
+	if err != nil {
+		fmt.Printf("%s❌ Impossible de lire le stash %s%s\n", ColorRed, entry.ref, ColorReset)
+		gm.pause()
+		return
+	}
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", entry.hash+"^3"); err == nil {
+		// Section distincte: l'import reconstruit le commit ^3 pour garder les fichiers non suivis
+		untracked, _ := gm.rawDiff("diff", "--binary", emptyTree, entry.hash+"^3")
+		patch += "\n" + stashUntrackedMarker + "\n" + untracked
+	}
+	base, _ := gm.runGitCommand("rev-parse", entry.hash+"^1")
+	header := fmt.Sprintf("%s%s\n# branche: %s\n# base: %s\n\n", stashPatchHeader, entry.message, entry.branch, base)
+
+	defaultPath := fmt.Sprintf("stash-%s.patch", slugify(entry.message))
+	fmt.Printf("%sFichier de destination (défaut: %s): %s", ColorYellow, defaultPath, ColorReset)
+	path := gm.getUserInput()
+	if path == "" {
+		path = defaultPath
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(gm.currentPath, path)
+	}
+	if err := os.WriteFile(path, []byte(header+patch), 0644); err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Stash %s exporté: %s%s\n", ColorGreen, entry.ref, path, ColorReset)
+		fmt.Printf("%s💡 Sur l'autre machine: Stash → Importer un patch comme stash%s\n", ColorCyan, ColorReset)
+	}
+	gm.pause()
+}
+
+// Importe un patch comme nouveau stash sans toucher à la copie de travail (index temporaire)
+func (gm *GitManager) importStash() {
+	fmt.Printf("%sFichier patch à importer: %s", ColorYellow, ColorReset)
+	path := gm.getUserInput()
+	if path == "" {
+		return
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(gm.currentPath, path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
+
+	message := "Importé depuis " + filepath.Base(path)
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, stashPatchHeader) {
+			message = strings.TrimPrefix(line, stashPatchHeader)
+			break
+		}
+	}
+	fmt.Printf("%sMessage du stash (défaut: %s): %s", ColorYellow, message, ColorReset)
+	if input := gm.getUserInput(); input != "" {
+		message = input
+	}
+
+	head, err := gm.runGitCommand("rev-parse", "HEAD")
+	if err != nil {
+		fmt.Printf("%s❌ Un commit est nécessaire pour créer un stash%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	root, err := gm.runGitCommand("rev-parse", "--show-toplevel")
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, root, ColorReset)
+		gm.pause()
+		return
+	}
+
+	indexFile, err := os.CreateTemp("", "gitman-stash-index-")
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
+	indexFile.Close()
+	defer os.Remove(indexFile.Name())
+	env := []string{"GIT_INDEX_FILE=" + indexFile.Name()}
+
+	trackedPatch, untrackedPatch, hasUntracked := strings.Cut(string(content), "\n"+stashUntrackedMarker+"\n")
+
+	// Changements suivis: appliqués sur HEAD dans un index temporaire, l'arbre obtenu devient le stash
+	tree, err := gm.buildTreeFromPatch(env, root, "HEAD", trackedPatch)
+	if err != nil {
+		fmt.Printf("%s❌ Le patch ne s'applique pas sur HEAD: %v%s\n", ColorRed, err, ColorReset)
+		fmt.Printf("%s💡 Placez-vous sur la base d'origine (indiquée dans l'en-tête du patch) puis réessayez.%s\n", ColorYellow, ColorReset)
+		gm.pause()
+		return
+	}
+	headTree, _ := gm.runGitCommand("rev-parse", "HEAD^{tree}")
+
+	// Fichiers non suivis: arbre indépendant (partant de zéro) pour le commit ^3
+	untrackedTree := ""
+	if hasUntracked && strings.TrimSpace(untrackedPatch) != "" {
+		if untrackedTree, err = gm.buildTreeFromPatch(env, root, "", untrackedPatch); err != nil {
+			fmt.Printf("%s❌ Fichiers non suivis illisibles: %v%s\n", ColorRed, err, ColorReset)
+			gm.pause()
+			return
+		}
+	}
+	if tree == headTree && untrackedTree == "" {
+		fmt.Printf("%s❌ Le patch n'apporte aucun changement par rapport à HEAD: aucun stash créé%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	branch := gm.getCurrentBranch()
+	subject, _ := gm.runGitCommand("log", "-1", "--format=%h %s", "HEAD")
+	stashMessage := fmt.Sprintf("On %s: %s", branch, message)
+	indexCommit, err := gm.runGitCommand("commit-tree", "HEAD^{tree}", "-p", head, "-m", fmt.Sprintf("index on %s: %s", branch, subject))
+	parents := []string{"-p", head, "-p", indexCommit}
+	if err == nil && untrackedTree != "" {
+		var untrackedCommit string
+		untrackedCommit, err = gm.runGitCommand("commit-tree", untrackedTree, "-m", fmt.Sprintf("untracked files on %s: %s", branch, subject))
+		parents = append(parents, "-p", untrackedCommit)
+	}
+	if err == nil {
+		var stashCommit string
+		stashCommit, err = gm.runGitCommand(append(append([]string{"commit-tree", tree}, parents...), "-m", stashMessage)...)
+		if err == nil {
+			_, err = gm.runGitCommand("stash", "store", "-m", stashMessage, stashCommit)
+		}
+	}
+	if err != nil {
+		fmt.Printf("%s❌ Création du stash échouée: %v%s\n", ColorRed, err, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Patch importé comme stash@{0}: %s%s\n", ColorGreen, message, ColorReset)
+	}
+	gm.pause()
+}
+
+// Applique un patch dans un index temporaire initialisé depuis base ("" pour un index vide) et renvoie l'arbre obtenu
+func (gm *GitManager) buildTreeFromPatch(env []string, root, base, patch string) (string, error) {
+	readTree := []string{"read-tree", "--empty"}
+	if base != "" {
+		readTree = []string{"read-tree", base}
+	}
+	if output, err := gm.runGitCommandWithEnv(env, "", readTree...); err != nil {
+		return "", fmt.Errorf("%s", output)
+	}
+	if strings.Contains(patch, "diff --git ") {
+		// Depuis un sous-répertoire, git apply ignorerait silencieusement les chemins situés ailleurs
+		if output, err := gm.runGitCommandWithEnv(env, patch, "-C", root, "apply", "--cached"); err != nil {
+			return "", fmt.Errorf("%s", output)
+		}
+	}
+	tree, err := gm.runGitCommandWithEnv(env, "", "write-tree")
+	if err != nil {
+		return "", fmt.Errorf("%s", tree)
+	}
+	return tree, nil
+}
+
+// Statistics
+func (gm *GitManager) showGeneralStats() {
+	fmt.Printf("%s%s📊 STATISTIQUES GÉNÉRALES%s\n", ColorBold, ColorBlue, ColorReset)
+	fmt.Println(strings.Repeat("═", 40))
+
+	totalCommits, _ := gm.runGitCommand("rev-list", "--count", "HEAD")
+	fmt.Printf("%s📦 Total commits: %s%s%s\n", ColorGreen, ColorWhite, totalCommits, ColorReset)
+
+	branches, _ := gm.runGitCommand("branch", "-r")
+	branchCount := 0
+	if branches != "" {
+		branchCount = len(strings.Split(strings.TrimSpace(branches), "\n"))
+	}
+	localBranches, _ := gm.runGitCommand("branch")
+	localCount := 0
+	if localBranches != "" {
+		localCount = len(strings.Split(strings.TrimSpace(localBranches), "\n"))
+	}
+
+	fmt.Printf("%s🌿 Branches locales: %s%d%s\n", ColorGreen, ColorWhite, localCount, ColorReset)
+	fmt.Printf("%s🌐 Branches remote: %s%d%s\n", ColorGreen, ColorWhite, branchCount, ColorReset)
+
+	tags, _ := gm.runGitCommand("tag")
+	tagCount := 0
+	if tags != "" {
+		tagCount = len(strings.Split(strings.TrimSpace(tags), "\n"))
+	}
+	fmt.Printf("%s🏷️  Tags: %s%d%s\n", ColorGreen, ColorWhite, tagCount, ColorReset)
+
+	firstCommit, _ := gm.runGitCommand("log", "--reverse", "--pretty=format:%h - %s (%an, %ad)", "--date=short", "-1")
+	lastCommit, _ := gm.runGitCommand("log", "--pretty=format:%h - %s (%an, %ad)", "--date=short", "-1")
+
+	fmt.Printf("\n%s📅 Premier commit:%s\n%s\n", ColorYellow, ColorReset, firstCommit)
+	fmt.Printf("%s📅 Dernier commit:%s\n%s\n", ColorYellow, ColorReset, lastCommit)
+
+	repoSize, _ := gm.runGitCommand("count-objects", "-vH")
+	fmt.Printf("\n%s💾 Taille du dépôt:%s\n", ColorCyan, ColorReset)
+	fmt.Println(repoSize)
+
+	gm.pause()
+}
+
+func (gm *GitManager) showContributorStats() {
+	fmt.Printf("%s%s👥 STATISTIQUES DES CONTRIBUTEURS%s\n", ColorBold, ColorBlue, ColorReset)
+	fmt.Println(strings.Repeat("═", 45))
+
+	contributors, _ := gm.runGitCommand("shortlog", "-sn", "--all")
+	fmt.Printf("%s📈 Commits par contributeur:%s\n", ColorGreen, ColorReset)
+	fmt.Println(contributors)
+
+	fmt.Printf("\n%s📅 Activité par mois (12 derniers mois):%s\n", ColorYellow, ColorReset)
+	activity, _ := gm.runGitCommand("log", "--pretty=format:%ad", "--date=format:%Y-%m", "--since=12.months.ago")
+	if activity != "" {
+		months := strings.Split(activity, "\n")
+		monthCount := make(map[string]int)
+		for _, month := range months {
+			monthCount[month]++
+		}
+
+		var sortedMonths []string
+		for month := range monthCount {
+			sortedMonths = append(sortedMonths, month)
+		}
+		sort.Strings(sortedMonths)
+
+		for _, month := range sortedMonths {
+			fmt.Printf("%s: %d commits\n", month, monthCount[month])
+		}
+	}
+
+	gm.pause()
+}
+
+func (gm *GitManager) showDetailedHistory() {
+	fmt.Printf("%sNombre de commits à afficher (défaut: 20): %s", ColorYellow, ColorReset)
+	countStr := gm.getUserInput()
+
+	count := 20
+	if countStr != "" {
+		if c, err := strconv.Atoi(countStr); err == nil {
+			count = c
+		}
+	}
+
+	fmt.Println("\n1. Format court")
+	fmt.Println("2. Format détaillé")
+	fmt.Println("3. Format personnalisé")
+
+	fmt.Printf("\n%sChoisissez un format: %s", ColorYellow, ColorReset)
+	choice := gm.getUserInput()
+
+	var output string
+	var err error
+
+	switch choice {
+	case "1":
+		output, err = gm.signedLog("%h%d %s", "--graph", "--decorate", fmt.Sprintf("-%d", count))
+	case "2":
+		output, err = gm.signedLog("%C(yellow)%h%C(reset) - %C(green)(%ar)%C(reset) %s %C(bold blue)<%an>%C(reset)%x1f%GS", "--graph", "--color=always", fmt.Sprintf("-%d", count))
+	case "3":
+		fmt.Printf("%sFormat personnalisé (ex: %%h - %%s (%%an)): %s", ColorYellow, ColorReset)
+		format := gm.getUserInput()
+		if format != "" {
+			output, err = gm.runGitCommand("log", "--pretty=format:"+format, fmt.Sprintf("-%d", count))
+		}
+	default:
+		output, err = gm.signedLog("%h %s", fmt.Sprintf("-%d", count))
+	}
+
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s📈 Historique:%s\n", ColorBlue, ColorReset)
+		fmt.Println(output)
+		if choice != "3" {
+			fmt.Printf("\n%sSignature: %s valide  %s confiance inconnue  %s invalide/révoquée  %s non vérifiable  %s non signé%s\n",
+				ColorCyan, signatureBadge("G"), signatureBadge("U"), signatureBadge("B"), signatureBadge("E"), signatureBadge("N"), ColorReset)
+		}
+
+		fmt.Printf("\n%sHash d'un commit non pushé à modifier (reword/edit/drop/split, vide pour revenir): %s", ColorYellow, ColorReset)
+		if hash := gm.getUserInput(); hash != "" {
+			gm.rewriteHistoryCommit(hash)
+		}
+		return
+	}
+
+	gm.pause()
+}
+
+func (gm *GitManager) showBranchGraph() {
+	fmt.Printf("%s%s🌳 GRAPHIQUE DES BRANCHES%s\n", ColorBold, ColorBlue, ColorReset)
+	fmt.Println(strings.Repeat("═", 35))
+
+	// Vérifier d'abord s'il y a plusieurs branches
+	localBranches, _ := gm.runGitCommand("branch")
+
+	branchCount := len(strings.Split(strings.TrimSpace(localBranches), "\n"))
+	if localBranches == "" {
+		branchCount = 0
+	}
+
+	fmt.Printf("%s📊 Analyse des branches:%s\n", ColorCyan, ColorReset)
+	fmt.Printf("   %sBranches locales: %s%d%s\n", ColorBlue, ColorYellow, branchCount, ColorReset)
+
+	if branchCount <= 1 {
+		fmt.Printf("%s💡 Vous n'avez qu'une seule branche, le graphique sera linéaire.%s\n", ColorYellow, ColorReset)
+		fmt.Printf("%s   Créez des branches pour voir un vrai graphique de développement !%s\n\n", ColorYellow, ColorReset)
+	}
+
+	fmt.Printf("%sNombre de commits à afficher (défaut: 30): %s", ColorYellow, ColorReset)
+	countStr := gm.getUserInput()
+
+	count := 30
+	if countStr != "" {
+		if c, err := strconv.Atoi(countStr); err == nil {
+			count = c
+		}
+	}
+
+	fmt.Printf("\n%sChoisissez le style d'affichage:%s\n", ColorCyan, ColorReset)
+	fmt.Println("1. Graphique simple (par défaut)")
+	fmt.Println("2. Graphique détaillé avec couleurs")
+	fmt.Println("3. Vue compacte (oneline)")
+	fmt.Println("4. Vue avec statistiques des fichiers")
+	fmt.Println("5. Vue par auteur")
+
+	fmt.Printf("\n%sStyle (défaut 2): %s", ColorYellow, ColorReset)
+	style := gm.getUserInput()
+
+	var output string
+	var err error
+
+	switch style {
+	case "1":
+		output, err = gm.runGitCommand("log", "--graph", "--pretty=format:%h - %s (%ar) <%an>", "--all", fmt.Sprintf("-%d", count))
+	case "3":
+		output, err = gm.runGitCommand("log", "--graph", "--oneline", "--all", fmt.Sprintf("-%d", count))
+	case "4":
+		output, err = gm.runGitCommand("log", "--graph", "--stat", "--pretty=format:%C(yellow)%h%C(reset) - %s %C(green)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)", fmt.Sprintf("-%d", count))
+	case "5":
+		// Vue par auteur avec couleurs
+		output, err = gm.runGitCommand("log", "--graph", "--pretty=format:%C(red)%h%C(reset) %C(green)%s%C(reset) %C(yellow)(%ar)%C(reset) %C(bold blue)by %an%C(reset)", "--all", fmt.Sprintf("-%d", count))
+	default: // Style 2 - détaillé avec couleurs (par défaut)
+		output, err = gm.runGitCommand("log", "--graph", "--pretty=format:%C(auto)%h%C(reset) %C(bold)-%C(reset) %C(white)%s%C(reset) %C(green)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)", "--all", fmt.Sprintf("-%d", count))
+	}
+
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("\n%s🌿 Historique des branches:%s\n", ColorGreen, ColorReset)
+		fmt.Println(strings.Repeat("─", 50))
+		fmt.Println(output)
+
+		// Ajouter des statistiques utiles
+		fmt.Printf("\n%s📈 Statistiques rapides:%s\n", ColorBlue, ColorReset)
+
+		// Dernière activité
+		lastCommit, _ := gm.runGitCommand("log", "-1", "--pretty=format:%ar")
+		fmt.Printf("   %sDernière activité: %s%s%s\n", ColorCyan, ColorWhite, lastCommit, ColorReset)
+
+		// Nombre total de commits
+		totalCommits, _ := gm.runGitCommand("rev-list", "--count", "HEAD")
+		fmt.Printf("   %sTotal commits: %s%s%s\n", ColorCyan, ColorWhite, totalCommits, ColorReset)
+
+		// Branches remote si elles existent
+		remoteBranches, _ := gm.runGitCommand("branch", "-r")
+		if remoteBranches != "" {
+			remoteCount := len(strings.Split(strings.TrimSpace(remoteBranches), "\n"))
+			fmt.Printf("   %sBranches remote: %s%d%s\n", ColorCyan, ColorWhite, remoteCount, ColorReset)
+		}
+	}
+
+	gm.pause()
+}
+
+// Nouvelle fonction pour afficher un arbre complet de tous les commits et branches
+func (gm *GitManager) showCompleteTree() {
+	fmt.Printf("%s%s🌳 VUE ARBRE COMPLÈTE - TOUS COMMITS & BRANCHES%s\n", ColorBold, ColorGreen, ColorReset)
+	fmt.Println(strings.Repeat("═", 55))
+
+	currentBranch := gm.getCurrentBranch()
+	fmt.Printf("%s📍 Branche actuelle: %s%s%s\n\n", ColorBlue, ColorCyan, currentBranch, ColorReset)
+
+	fmt.Printf("%sNombre de commits à afficher (défaut: 50): %s", ColorYellow, ColorReset)
+	countStr := gm.getUserInput()
+
+	count := 50
+	if countStr != "" {
+		if c, err := strconv.Atoi(countStr); err == nil {
+			count = c
+		}
+	}
+
+	fmt.Printf("\n%sStyle d'arbre:%s\n", ColorCyan, ColorReset)
+	fmt.Println("1. Arbre complet avec toutes les branches (recommandé)")
+	fmt.Println("2. Arbre avec couleurs et décoration avancée")
+	fmt.Println("3. Arbre compact avec hash courts")
+	fmt.Println("4. Arbre avec informations de merge")
+	fmt.Println("5. Arbre ASCII artistique")
+
+	fmt.Printf("\n%sStyle (défaut 1): %s", ColorYellow, ColorReset)
+	style := gm.getUserInput()
+
+	var output string
+	var err error
+
+	switch style {
+	case "2":
+		// Arbre avec couleurs et décoration avancée
+		output, err = gm.runGitCommand("log",
+			"--graph",
+			"--all",
+			"--decorate",
+			"--pretty=format:%C(auto)%h%C(reset) %C(bold yellow)│%C(reset) %C(white)%s%C(reset) %C(dim)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)%C(auto)%d%C(reset)",
+			fmt.Sprintf("-%d", count))
+	case "3":
+		// Arbre compact
+		output, err = gm.runGitCommand("log",
+			"--graph",
+			"--all",
+			"--oneline",
+			"--decorate",
+			fmt.Sprintf("-%d", count))
+	case "4":
+		// Arbre avec informations de merge
+		output, err = gm.runGitCommand("log",
+			"--graph",
+			"--all",
+			"--decorate",
+			"--merges",
+			"--pretty=format:%C(yellow)%h%C(reset) %C(bold)MERGE:%C(reset) %C(white)%s%C(reset) %C(green)(%ar)%C(reset) %C(blue)<%an>%C(reset)%C(auto)%d%C(reset)",
+			fmt.Sprintf("-%d", count))
+	case "5":
+		// Arbre ASCII artistique
+		output, err = gm.runGitCommand("log",
+			"--graph",
+			"--all",
+			"--decorate",
+			"--pretty=format:%C(red)●%C(reset) %C(yellow)%h%C(reset) %C(bold)━%C(reset) %C(white)%s%C(reset)%C(auto)%d%C(reset)%n%C(dim)  ╰─ %ar by %an%C(reset)",
+			fmt.Sprintf("-%d", count))
+	default: // Style 1 - Arbre complet (par défaut)
+		output, err = gm.runGitCommand("log",
+			"--graph",
+			"--all",
+			"--decorate",
+			"--date=relative",
+			"--pretty=format:%C(auto)%h%C(reset) - %C(white)%s%C(reset) %C(green)(%ar)%C(reset) %C(bold blue)<%an>%C(reset)%C(auto)%d%C(reset)",
+			fmt.Sprintf("-%d", count))
+	}
+
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
+
+	// Afficher l'arbre
+	fmt.Printf("\n%s🌿 ARBRE COMPLET:%s\n", ColorGreen, ColorReset)
+	fmt.Println(strings.Repeat("─", 80))
+	fmt.Println(output)
+
+	// Ajouter un résumé des branches
+	fmt.Printf("\n%s📊 RÉSUMÉ DES BRANCHES:%s\n", ColorBlue, ColorReset)
+	gm.showBranchSummary()
+
+	gm.pause()
+}
+
+// Fonction helper pour afficher un résumé des branches
+func (gm *GitManager) showBranchSummary() {
+	// Branches locales avec leurs derniers commits
+	localBranches, _ := gm.runGitCommand("for-each-ref",
+		"--format=%(refname:short)|%(objectname:short)|%(committerdate:relative)|%(subject)",
+		"refs/heads/")
+
+	if localBranches != "" {
+		fmt.Printf("%s🏠 Branches locales:%s\n", ColorCyan, ColorReset)
+		lines := strings.Split(localBranches, "\n")
+		for _, line := range lines {
+			if line != "" {
+				parts := strings.Split(line, "|")
+				if len(parts) >= 4 {
+					branchName := parts[0]
+					hash := parts[1]
+					date := parts[2]
+					subject := parts[3]
+
+					// Limiter la longueur du message
+					if len(subject) > 50 {
+						subject = subject[:47] + "..."
+					}
+
+					currentBranch := gm.getCurrentBranch()
+					if branchName == currentBranch {
+						fmt.Printf("   %s* %-15s%s %s%s%s (%s) - %s\n",
+							ColorGreen, branchName, ColorReset, ColorYellow, hash, ColorReset, date, subject)
+					} else {
+						fmt.Printf("     %-15s %s%s%s (%s) - %s\n",
+							branchName, ColorYellow, hash, ColorReset, date, subject)
+					}
+				}
+			}
+		}
+	}
+
+	// Branches remote si elles existent
+	remoteBranches, _ := gm.runGitCommand("for-each-ref",
+		"--format=%(refname:short)|%(objectname:short)|%(committerdate:relative)",
+		"refs/remotes/")
+
+	if remoteBranches != "" {
+		fmt.Printf("\n%s🌐 Branches remote:%s\n", ColorCyan, ColorReset)
+		lines := strings.Split(remoteBranches, "\n")
+		for _, line := range lines {
+			if line != "" {
+				parts := strings.Split(line, "|")
+				if len(parts) >= 3 {
+					branchName := parts[0]
+					hash := parts[1]
+					date := parts[2]
+					fmt.Printf("     %-20s %s%s%s (%s)\n",
+						branchName, ColorYellow, hash, ColorReset, date)
+				}
+			}
+		}
+	}
+
+	// Statistiques rapides
+	totalCommits, _ := gm.runGitCommand("rev-list", "--count", "--all")
+	fmt.Printf("\n%s📈 Total commits (toutes branches): %s%s%s\n", ColorGreen, ColorWhite, totalCommits, ColorReset)
+}
+
+// Fonction bonus pour visualiser l'arbre des branches de manière plus graphique
+func (gm *GitManager) showBranchTree() {
+	fmt.Printf("%s%s🌲 ARBRE DES BRANCHES%s\n", ColorBold, ColorGreen, ColorReset)
+	fmt.Println(strings.Repeat("═", 25))
+
+	// Lister toutes les branches avec leurs derniers commits
+	branches, _ := gm.runGitCommand("branch", "-v")
+	fmt.Printf("%s📋 Branches locales:%s\n", ColorBlue, ColorReset)
+	fmt.Println(branches)
+
+	// Afficher les relations entre branches
+	fmt.Printf("\n%s🔗 Relations entre branches:%s\n", ColorYellow, ColorReset)
+
+	// Pour chaque branche, montrer depuis quel commit elle diverge
+	localBranches, _ := gm.runGitCommand("branch", "--format=%(refname:short)")
+	if localBranches != "" {
+		branchLines := strings.Split(strings.TrimSpace(localBranches), "\n")
+		currentBranch := gm.getCurrentBranch()
+
+		for _, branch := range branchLines {
+			if branch != "" && branch != currentBranch {
+				// Trouver le commit de divergence
+				mergeBase, _ := gm.runGitCommand("merge-base", currentBranch, branch)
+				if mergeBase != "" {
+					shortHash := mergeBase[:7]
+					commitMsg, _ := gm.runGitCommand("log", "-1", "--pretty=format:%s", mergeBase)
+					fmt.Printf("   %s%s%s ──┬── %s%s%s\n", ColorCyan, currentBranch, ColorReset, ColorYellow, branch, ColorReset)
+					fmt.Printf("      │   └─ diverge depuis: %s%s - %s%s\n", ColorGreen, shortHash, commitMsg, ColorReset)
+				}
+			}
+		}
+
+		if len(branchLines) == 1 {
+			fmt.Printf("   %s%s%s (branche unique)\n", ColorGreen, currentBranch, ColorReset)
+			fmt.Printf("   └─ %s💡 Créez des branches pour voir les relations !%s\n", ColorYellow, ColorReset)
+		}
+	}
+
+	gm.pause()
+}
+
+func (gm *GitManager) showFileStats() {
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s%s📁 STATISTIQUES PAR FICHIER%s\n", ColorBold, ColorBlue, ColorReset)
+		fmt.Println(strings.Repeat("═", 35))
+
+		fmt.Println("1. Fichiers les plus modifiés")
+		fmt.Println("2. Lignes ajoutées/supprimées par fichier")
+		fmt.Println("3. Historique d'un fichier spécifique")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			output, err := gm.runGitCommand("log", "--pretty=format:", "--name-only")
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				files := strings.Split(output, "\n")
+				fileCount := make(map[string]int)
+				for _, file := range files {
+					if file != "" {
+						fileCount[file]++
+					}
+				}
+
+				type fileInfo struct {
+					name  string
+					count int
+				}
+				var sortedFiles []fileInfo
+				for name, count := range fileCount {
+					sortedFiles = append(sortedFiles, fileInfo{name, count})
+				}
+
+				sort.Slice(sortedFiles, func(i, j int) bool {
+					return sortedFiles[i].count > sortedFiles[j].count
+				})
+
+				fmt.Printf("%s📊 Fichiers les plus modifiés:%s\n", ColorGreen, ColorReset)
+				for i, file := range sortedFiles {
+					if i >= 20 {
+						break
+					}
+					fmt.Printf("%s%3d%s modifications - %s\n", ColorYellow, file.count, ColorReset, file.name)
+				}
+			}
+			gm.pause()
+
+		case "2":
+			output, err := gm.runGitCommand("log", "--numstat", "--pretty=format:")
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				lines := strings.Split(output, "\n")
+				fileStats := make(map[string][2]int) // [additions, deletions]
+
+				for _, line := range lines {
+					if line != "" {
+						parts := strings.Fields(line)
+						if len(parts) == 3 {
 							if parts[0] == "-" { // Binary file
 								continue
 							}
@@ -2683,243 +7725,1244 @@ func (gm *GitManager) showFileStats() {
 					}
 				}
 
-				fmt.Printf("%s📊 Lignes ajoutées/supprimées:%s\n", ColorGreen, ColorReset)
-				for filename, stats := range fileStats {
-					fmt.Printf("%s+%d%s %s-%d%s %s\n", ColorGreen, stats[0], ColorReset, ColorRed, stats[1], ColorReset, filename)
-				}
-			}
+				fmt.Printf("%s📊 Lignes ajoutées/supprimées:%s\n", ColorGreen, ColorReset)
+				for filename, stats := range fileStats {
+					fmt.Printf("%s+%d%s %s-%d%s %s\n", ColorGreen, stats[0], ColorReset, ColorRed, stats[1], ColorReset, filename)
+				}
+			}
+			gm.pause()
+
+		case "3":
+			fmt.Printf("%sNom du fichier: %s", ColorYellow, ColorReset)
+			filename := gm.getUserInput()
+			if filename != "" {
+				output, err := gm.runGitCommand("log", "--follow", "--oneline", "--", filename)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				} else {
+					fmt.Printf("%s📊 Historique de '%s':%s\n", ColorGreen, filename, ColorReset)
+					fmt.Println(output)
+				}
+			}
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) showBlame() {
+	fmt.Printf("%sNom du fichier à analyser: %s", ColorYellow, ColorReset)
+	filename := gm.getUserInput()
+
+	if filename == "" {
+		return
+	}
+
+	if _, err := os.Stat(filepath.Join(gm.currentPath, filename)); os.IsNotExist(err) {
+		fmt.Printf("%s❌ Fichier '%s' introuvable!%s\n", ColorRed, filename, ColorReset)
+		gm.pause()
+		return
+	}
+
+	for {
+		gm.clearScreen()
+		fmt.Println("1. Blâme complet")
+		fmt.Println("2. Blâme avec statistiques")
+		fmt.Println("3. Blâme d'une plage de lignes")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		var output string
+		var err error
+
+		switch choice {
+		case "1":
+			output, err = gm.runGitCommand("blame", filename)
+		case "2":
+			output, err = gm.runGitCommand("blame", "-w", "-C", "-C", "-C", filename)
+		case "3":
+			fmt.Printf("%sLigne de début: %s", ColorYellow, ColorReset)
+			startLine := gm.getUserInput()
+			fmt.Printf("%sLigne de fin: %s", ColorYellow, ColorReset)
+			endLine := gm.getUserInput()
+
+			if startLine != "" && endLine != "" {
+				lineRange := fmt.Sprintf("-L%s,%s", startLine, endLine)
+				output, err = gm.runGitCommand("blame", lineRange, filename)
+			}
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			continue
+		}
+
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s🔍 Blâme de '%s':%s\n", ColorBlue, filename, ColorReset)
+			fmt.Println(output)
+		}
+		gm.pause()
+	}
+}
+
+func (gm *GitManager) searchHistory() {
+	for {
+		gm.clearScreen()
+		fmt.Println("1. Rechercher dans les messages de commit")
+		fmt.Println("2. Rechercher dans le code (diff)")
+		fmt.Println("3. Rechercher par auteur")
+		fmt.Println("4. Rechercher par date")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		var output string
+		var err error
+		var args []string
+
+		switch choice {
+		case "1":
+			fmt.Printf("%sTerme à rechercher: %s", ColorYellow, ColorReset)
+			term := gm.getUserInput()
+			if term != "" {
+				args = []string{"log", "--grep=" + term, "--oneline"}
+				output, err = gm.runGitCommand(args...)
+				fmt.Printf("%s🔍 Commits contenant '%s':%s\n", ColorBlue, term, ColorReset)
+			}
+		case "2":
+			fmt.Printf("%sCode à rechercher: %s", ColorYellow, ColorReset)
+			code := gm.getUserInput()
+			if code != "" {
+				args = []string{"log", "-S" + code, "--oneline"}
+				output, err = gm.runGitCommand(args...)
+				fmt.Printf("%s🔍 Commits modifiant '%s':%s\n", ColorBlue, code, ColorReset)
+			}
+		case "3":
+			fmt.Printf("%sAuteur à rechercher: %s", ColorYellow, ColorReset)
+			author := gm.getUserInput()
+			if author != "" {
+				args = []string{"log", "--author=" + author, "--oneline"}
+				output, err = gm.runGitCommand(args...)
+				fmt.Printf("%s🔍 Commits de '%s':%s\n", ColorBlue, author, ColorReset)
+			}
+		case "4":
+			fmt.Printf("%sDate de début (YYYY-MM-DD): %s", ColorYellow, ColorReset)
+			since := gm.getUserInput()
+			fmt.Printf("%sDate de fin (YYYY-MM-DD, optionnel): %s", ColorYellow, ColorReset)
+			until := gm.getUserInput()
+
+			if since != "" {
+				args = []string{"log", "--oneline", "--since=" + since}
+				if until != "" {
+					args = append(args, "--until="+until)
+				}
+				output, err = gm.runGitCommand(args...)
+				fmt.Printf("%s🔍 Commits depuis %s", ColorBlue, since)
+				if until != "" {
+					fmt.Printf(" jusqu'à %s", until)
+				}
+				fmt.Printf(":%s\n", ColorReset)
+			}
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			continue
+		}
+
+		if err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else if output == "" {
+			fmt.Printf("%s❌ Aucun résultat%s\n", ColorRed, ColorReset)
+		} else {
+			fmt.Println(output)
+		}
+		gm.pause()
+	}
+}
+
+// Tools
+func (gm *GitManager) manageConfig() {
+	for {
+		gm.clearScreen()
+		fmt.Println("1. Voir la configuration")
+		fmt.Println("2. Configurer utilisateur")
+		fmt.Println("3. Configurer email")
+		fmt.Println("4. Autres configurations")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			output, err := gm.runGitCommand("config", "--list")
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				fmt.Printf("%s⚙️  Configuration actuelle:%s\n", ColorBlue, ColorReset)
+				fmt.Println(output)
+			}
+			gm.pause()
+		case "2":
+			fmt.Printf("%sNom d'utilisateur: %s", ColorYellow, ColorReset)
+			username := gm.getUserInput()
+			if username != "" {
+				output, err := gm.runGitCommand("config", "user.name", username)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				} else {
+					fmt.Printf("%s✅ Nom d'utilisateur configuré!%s\n", ColorGreen, ColorReset)
+				}
+			}
+			gm.pause()
+		case "3":
+			fmt.Printf("%sEmail: %s", ColorYellow, ColorReset)
+			email := gm.getUserInput()
+			if email != "" {
+				output, err := gm.runGitCommand("config", "user.email", email)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				} else {
+					fmt.Printf("%s✅ Email configuré!%s\n", ColorGreen, ColorReset)
+				}
+			}
+			gm.pause()
+		case "4":
+			fmt.Println("Configurations rapides:")
+			fmt.Println("a. Activer la couleur")
+			fmt.Println("b. Configurer l'éditeur par défaut")
+			fmt.Println("c. Configurer le push par défaut")
+			fmt.Println("d. Règle de nommage des branches (regex)")
+			fmt.Println("e. Modèle et types de noms de branches")
+			fmt.Println("f. Branches protégées")
+			fmt.Println("g. Stratégie de pull par défaut")
+			fmt.Println("h. Conventional Commits (validation, types, scopes)")
+			fmt.Println("i. Scan pré-commit (secrets, taille max, allowlist)")
+
+			fmt.Printf("\n%sChoisissez: %s", ColorYellow, ColorReset)
+			subChoice := gm.getUserInput()
+
+			switch subChoice {
+			case "a":
+				gm.runGitCommand("config", "color.ui", "auto")
+				fmt.Printf("%s✅ Couleur activée!%s\n", ColorGreen, ColorReset)
+			case "b":
+				fmt.Printf("%sÉditeur (nano, vim, code, etc.): %s", ColorYellow, ColorReset)
+				editor := gm.getUserInput()
+				if editor != "" {
+					gm.runGitCommand("config", "core.editor", editor)
+					fmt.Printf("%s✅ Éditeur configuré!%s\n", ColorGreen, ColorReset)
+				}
+			case "c":
+				gm.runGitCommand("config", "push.default", "simple")
+				fmt.Printf("%s✅ Push par défaut configuré!%s\n", ColorGreen, ColorReset)
+			case "d":
+				fmt.Printf("%sRègle actuelle: %s%s\n", ColorBlue, gm.getGitConfig("gitman.branchPattern"), ColorReset)
+				fmt.Printf("%sNouvelle regex (ex: ^(feature|fix|chore)/[A-Z]+-\\d+-.+, '-' pour supprimer): %s", ColorYellow, ColorReset)
+				pattern := gm.getUserInput()
+				if pattern == "-" {
+					gm.runGitCommand("config", "--unset", "gitman.branchPattern")
+					fmt.Printf("%s✅ Règle de nommage supprimée!%s\n", ColorGreen, ColorReset)
+				} else if pattern != "" {
+					if _, err := regexp.Compile(pattern); err != nil {
+						fmt.Printf("%s❌ Regex invalide: %v%s\n", ColorRed, err, ColorReset)
+					} else {
+						gm.runGitCommand("config", "gitman.branchPattern", pattern)
+						fmt.Printf("%s✅ Règle de nommage configurée!%s\n", ColorGreen, ColorReset)
+					}
+				}
+			case "e":
+				fmt.Printf("%sModèle (variables {type} {ticket} {slug}, défaut {type}/{ticket}-{slug}): %s", ColorYellow, ColorReset)
+				if template := gm.getUserInput(); template != "" {
+					gm.runGitCommand("config", "gitman.branchTemplate", template)
+				}
+				fmt.Printf("%sTypes séparés par des virgules (défaut feature,fix,chore): %s", ColorYellow, ColorReset)
+				if types := gm.getUserInput(); types != "" {
+					gm.runGitCommand("config", "gitman.branchTypes", types)
+				}
+				fmt.Printf("%s✅ Modèle de branches configuré!%s\n", ColorGreen, ColorReset)
+			case "f":
+				protected := gm.getGitConfigList("gitman.protectedBranches", []string{"main", "master"})
+				fmt.Printf("%sBranches protégées actuelles: %s%s\n", ColorBlue, strings.Join(protected, ", "), ColorReset)
+				fmt.Printf("%sNouvelle liste (motifs séparés par des virgules, ex: main,release/*): %s", ColorYellow, ColorReset)
+				if list := gm.getUserInput(); list != "" {
+					gm.runGitCommand("config", "gitman.protectedBranches", list)
+				}
+				fmt.Printf("%sMode: 1. confirmation par saisie du nom  2. blocage total: %s", ColorYellow, ColorReset)
+				switch gm.getUserInput() {
+				case "1":
+					gm.runGitCommand("config", "gitman.protectedMode", "confirm")
+				case "2":
+					gm.runGitCommand("config", "gitman.protectedMode", "block")
+				}
+				fmt.Printf("%s✅ Protection des branches configurée!%s\n", ColorGreen, ColorReset)
+			case "g":
+				fmt.Printf("%sStratégie actuelle: %s%s\n", ColorBlue, gm.getDefaultPullMode(), ColorReset)
+				fmt.Printf("%s1. merge  2. rebase  3. ff-only: %s", ColorYellow, ColorReset)
+				modes := map[string]string{"1": "merge", "2": "rebase", "3": "ff-only"}
+				if mode, ok := modes[gm.getUserInput()]; ok {
+					gm.runGitCommand("config", "gitman.pullMode", mode)
+					fmt.Printf("%s✅ Stratégie de pull configurée: %s%s\n", ColorGreen, mode, ColorReset)
+				}
+			case "h":
+				fmt.Printf("%sValider les messages libres selon Conventional Commits? (y/N): %s", ColorYellow, ColorReset)
+				enabled := "false"
+				if strings.ToLower(gm.getUserInput()) == "y" {
+					enabled = "true"
+				}
+				gm.runGitCommand("config", "gitman.conventionalCommits", enabled)
+				fmt.Printf("%sTypes autorisés (actuels: %s, vide pour conserver): %s", ColorYellow, strings.Join(gm.getCommitTypes(), ","), ColorReset)
+				if types := gm.getUserInput(); types != "" {
+					gm.runGitCommand("config", "gitman.commitTypes", types)
+				}
+				fmt.Printf("%sScopes autorisés (séparés par des virgules, vide pour conserver, '-' pour tous): %s", ColorYellow, ColorReset)
+				if scopes := gm.getUserInput(); scopes == "-" {
+					gm.runGitCommand("config", "--unset", "gitman.commitScopes")
+				} else if scopes != "" {
+					gm.runGitCommand("config", "gitman.commitScopes", scopes)
+				}
+				fmt.Printf("%s✅ Conventional Commits configuré!%s\n", ColorGreen, ColorReset)
+			case "i":
+				fmt.Printf("%sActiver le scan pré-commit? (y/N): %s", ColorYellow, ColorReset)
+				enabled := "false"
+				if strings.ToLower(gm.getUserInput()) == "y" {
+					enabled = "true"
+				}
+				gm.runGitCommand("config", "gitman.secretScan", enabled)
+				fmt.Printf("%sTaille maximale d'un fichier (ex: 5M, 500k; vide pour conserver): %s", ColorYellow, ColorReset)
+				if size := gm.getUserInput(); size != "" {
+					if _, err := parseSize(size); err != nil {
+						fmt.Printf("%s❌ Taille invalide: %s%s\n", ColorRed, size, ColorReset)
+					} else {
+						gm.runGitCommand("config", "gitman.maxFileSize", size)
+					}
+				}
+				fmt.Printf("%sChemins autorisés (motifs séparés par des virgules, vide pour conserver, '-' pour vider): %s", ColorYellow, ColorReset)
+				if allowlist := gm.getUserInput(); allowlist == "-" {
+					gm.runGitCommand("config", "--unset", "gitman.scanAllowlist")
+				} else if allowlist != "" {
+					gm.runGitCommand("config", "gitman.scanAllowlist", allowlist)
+				}
+				fmt.Printf("%s✅ Scan pré-commit configuré!%s\n", ColorGreen, ColorReset)
+			}
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) cleanRepo() {
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s🧹 NETTOYAGE DU DÉPÔT%s\n", ColorYellow, ColorReset)
+		fmt.Println(strings.Repeat("═", 25))
+
+		fmt.Println("1. Nettoyer les fichiers non trackés (clean)")
+		fmt.Println("2. Nettoyer les objets inaccessibles (prune)")
+		fmt.Println("3. Optimiser le dépôt (gc)")
+		fmt.Println("4. Nettoyage complet")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			preview, _ := gm.runGitCommand("clean", "-n")
+			if preview != "" {
+				fmt.Printf("%sFichiers qui seront supprimés:%s\n", ColorRed, ColorReset)
+				fmt.Println(preview)
+
+				fmt.Printf("\n%sContinuer? (y/N): %s", ColorYellow, ColorReset)
+				confirm := gm.getUserInput()
+				if strings.ToLower(confirm) == "y" {
+					output, err := gm.runGitCommand("clean", "-f")
+					if err != nil {
+						fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+					} else {
+						fmt.Printf("%s✅ Fichiers non trackés supprimés!%s\n", ColorGreen, ColorReset)
+					}
+				}
+			} else {
+				fmt.Printf("%s✅ Aucun fichier à nettoyer!%s\n", ColorGreen, ColorReset)
+			}
+			gm.pause()
+		case "2":
+			fmt.Printf("%sNettoyage des objets inaccessibles...%s\n", ColorYellow, ColorReset)
+			output, err := gm.runGitCommand("gc", "--prune=now")
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				fmt.Printf("%s✅ Objets inaccessibles nettoyés!%s\n", ColorGreen, ColorReset)
+			}
+			gm.pause()
+		case "3":
+			fmt.Printf("%sOptimisation du dépôt...%s\n", ColorYellow, ColorReset)
+			output, err := gm.runGitCommand("gc", "--aggressive", "--prune=now")
+			if err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			} else {
+				fmt.Printf("%s✅ Dépôt optimisé!%s\n", ColorGreen, ColorReset)
+			}
+			gm.pause()
+		case "4":
+			fmt.Printf("%s⚠️  Nettoyage complet (peut prendre du temps). Continuer? (y/N): %s", ColorRed, ColorReset)
+			confirm := gm.getUserInput()
+			if strings.ToLower(confirm) == "y" {
+				fmt.Printf("%sNettoyage en cours...%s\n", ColorYellow, ColorReset)
+				gm.runGitCommand("clean", "-f", "-d")
+				gm.runGitCommand("gc", "--aggressive", "--prune=now")
+				gm.runGitCommand("reflog", "expire", "--expire=now", "--all")
+				fmt.Printf("%s✅ Nettoyage complet terminé!%s\n", ColorGreen, ColorReset)
+			}
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) checkRepo() {
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s🔍 VÉRIFICATION DU DÉPÔT%s\n", ColorBlue, ColorReset)
+		fmt.Println(strings.Repeat("═", 30))
+
+		fmt.Println("1. Vérifier l'intégrité (fsck)")
+		fmt.Println("2. Statistiques des objets (count-objects)")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			fmt.Printf("%sVérification de l'intégrité...%s\n", ColorYellow, ColorReset)
+			output, err := gm.runGitCommand("fsck", "--full")
+			if err != nil || output != "" {
+				fmt.Printf("%s❌ Problèmes détectés:%s\n", ColorRed, ColorReset)
+				fmt.Println(output)
+			} else {
+				fmt.Printf("%s✅ Intégrité vérifiée! Aucun problème trouvé.%s\n", ColorGreen, ColorReset)
+			}
+			gm.pause()
+		case "2":
+			fmt.Printf("%s📊 Statistiques détaillées:%s\n", ColorBlue, ColorReset)
+			output, _ := gm.runGitCommand("count-objects", "-vH")
+			fmt.Println(output)
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) manageHooks() {
+	hooksDir := gm.gitPath("hooks")
+
+	for {
+		gm.clearScreen()
+		fmt.Printf("%s🪝 GESTION DES HOOKS%s\n", ColorPurple, ColorReset)
+		fmt.Println(strings.Repeat("═", 25))
+
+		hooks, err := os.ReadDir(hooksDir)
+		if err != nil {
+			fmt.Printf("%s❌ Impossible de lire le répertoire hooks%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+
+		fmt.Printf("%s📋 Hooks disponibles:%s\n", ColorBlue, ColorReset)
+		for _, hook := range hooks {
+			if !strings.HasSuffix(hook.Name(), ".sample") {
+				fmt.Printf("%s✅ %s%s (actif)\n", ColorGreen, hook.Name(), ColorReset)
+			} else {
+				fmt.Printf("%s⚪ %s%s (exemple)\n", ColorYellow, hook.Name(), ColorReset)
+			}
+		}
+
+		fmt.Println("\n1. Activer un hook d'exemple")
+		fmt.Println("2. Désactiver un hook")
+		fmt.Println("3. Voir le contenu d'un hook")
+		fmt.Println("4. Créer un hook personnalisé")
+		fmt.Println("0. Retour")
+
+		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
+		choice := gm.getUserInput()
+
+		switch choice {
+		case "1":
+			fmt.Printf("%sNom du hook à activer (sans .sample): %s", ColorYellow, ColorReset)
+			hookName := gm.getUserInput()
+			if hookName != "" {
+				samplePath := filepath.Join(hooksDir, hookName+".sample")
+				hookPath := filepath.Join(hooksDir, hookName)
+
+				if _, err := os.Stat(samplePath); err == nil {
+					content, _ := os.ReadFile(samplePath)
+					err := os.WriteFile(hookPath, content, 0755)
+					if err != nil {
+						fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, err.Error(), ColorReset)
+					} else {
+						fmt.Printf("%s✅ Hook '%s' activé!%s\n", ColorGreen, hookName, ColorReset)
+					}
+				} else {
+					fmt.Printf("%s❌ Hook d'exemple '%s.sample' introuvable!%s\n", ColorRed, hookName, ColorReset)
+				}
+			}
+			gm.pause()
+		case "2":
+			fmt.Printf("%sNom du hook à désactiver: %s", ColorYellow, ColorReset)
+			hookName := gm.getUserInput()
+			if hookName != "" {
+				hookPath := filepath.Join(hooksDir, hookName)
+				err := os.Remove(hookPath)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, err.Error(), ColorReset)
+				} else {
+					fmt.Printf("%s✅ Hook '%s' désactivé!%s\n", ColorGreen, hookName, ColorReset)
+				}
+			}
+			gm.pause()
+		case "3":
+			fmt.Printf("%sNom du hook à voir: %s", ColorYellow, ColorReset)
+			hookName := gm.getUserInput()
+			if hookName != "" {
+				hookPath := filepath.Join(hooksDir, hookName)
+				content, err := os.ReadFile(hookPath)
+				if err != nil {
+					fmt.Printf("%s❌ Hook introuvable: %s%s\n", ColorRed, err.Error(), ColorReset)
+				} else {
+					fmt.Printf("%s📄 Contenu du hook '%s':%s\n", ColorBlue, hookName, ColorReset)
+					fmt.Println(string(content))
+				}
+			}
+			gm.pause()
+		case "4":
+			fmt.Printf("%sNom du nouveau hook: %s", ColorYellow, ColorReset)
+			hookName := gm.getUserInput()
+			if hookName != "" {
+				hookContent := `#!/bin/sh
+# Hook personnalisé: ` + hookName + `
+# Créé le ` + time.Now().Format("2006-01-02 15:04:05") + `
+
+echo "Exécution du hook ` + hookName + `"
+
+# Ajoutez votre code ici
+exit 0
+`
+				hookPath := filepath.Join(hooksDir, hookName)
+				err := os.WriteFile(hookPath, []byte(hookContent), 0755)
+				if err != nil {
+					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, err.Error(), ColorReset)
+				} else {
+					fmt.Printf("%s✅ Hook '%s' créé!%s\n", ColorGreen, hookName, ColorReset)
+				}
+			}
+			gm.pause()
+		case "0":
+			return
+		default:
+			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) manageAliases() {
+	// This is a complex feature, so we'll provide a simpler interface
+	fmt.Printf("%sLes alias Git sont gérés dans votre fichier de configuration Git (~/.gitconfig).%s\n", ColorYellow, ColorReset)
+	fmt.Println("Exemples d'alias utiles:")
+	fmt.Println("  git config --global alias.co checkout")
+	fmt.Println("  git config --global alias.br branch")
+	fmt.Println("  git config --global alias.ci commit")
+	fmt.Println("  git config --global alias.st status")
+	fmt.Println("  git config --global alias.last 'log -1 HEAD'")
+	gm.pause()
+}
+
+func (gm *GitManager) archiveRepo() {
+	fmt.Printf("%sFormat d'archive (zip, tar.gz): %s", ColorYellow, ColorReset)
+	format := gm.getUserInput()
+	if format != "zip" && format != "tar.gz" {
+		fmt.Printf("%s❌ Format invalide. Utilisez 'zip' ou 'tar.gz'.%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	defaultFileName := filepath.Base(gm.currentPath) + "." + format
+	fmt.Printf("%sNom du fichier de sortie (défaut: %s): %s", ColorYellow, defaultFileName, ColorReset)
+	outputFile := gm.getUserInput()
+	if outputFile == "" {
+		outputFile = defaultFileName
+	}
+
+	output, err := gm.runGitCommand("archive", "--format="+format, "-o", outputFile, "HEAD")
+	if err != nil {
+		fmt.Printf("%s❌ Erreur lors de la création de l'archive: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Archive '%s' créée avec succès!%s\n", ColorGreen, outputFile, ColorReset)
+	}
+	gm.pause()
+}
+
+// Bisect
+func (gm *GitManager) bisectInProgress() bool {
+	_, err := os.Stat(gm.gitPath("BISECT_START"))
+	return err == nil
+}
+
+// Premier commit fautif trouvé par la session de bisect en cours ("" si pas encore trouvé)
+func (gm *GitManager) bisectFirstBad() string {
+	log, _ := gm.runGitCommand("bisect", "log")
+	for _, line := range strings.Split(log, "\n") {
+		if strings.HasPrefix(line, "# first bad commit: [") {
+			hash, _, _ := strings.Cut(strings.TrimPrefix(line, "# first bad commit: ["), "]")
+			return hash
+		}
+	}
+	return ""
+}
+
+// Nombre de commits candidats et étapes estimées restantes (git rev-list --bisect-vars)
+func (gm *GitManager) bisectProgress() (candidates, steps int) {
+	goods, _ := gm.runGitCommand("for-each-ref", "--format=%(objectname)", "refs/bisect/good-*")
+	args := []string{"rev-list", "--bisect-vars", "refs/bisect/bad"}
+	if goods != "" {
+		args = append(append(args, "--not"), strings.Split(goods, "\n")...)
+	}
+	output, err := gm.runGitCommand(args...)
+	if err != nil {
+		return 0, 0
+	}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, _ := strings.Cut(line, "=")
+		switch key {
+		case "bisect_all":
+			candidates, _ = strconv.Atoi(value)
+		case "bisect_steps":
+			steps, _ = strconv.Atoi(value)
+		}
+	}
+	return candidates, steps
+}
+
+// Choix d'une révision parmi les tags et l'historique récent, ou saisie libre
+func (gm *GitManager) pickRevision(prompt, defaultRev string) string {
+	var choices []string
+	tags, _ := gm.runGitCommand("tag", "--sort=-creatordate")
+	if tags != "" {
+		fmt.Printf("%s🏷️  Tags:%s\n", ColorBlue, ColorReset)
+		for i, tag := range strings.Split(tags, "\n") {
+			if i == 8 {
+				break
+			}
+			choices = append(choices, tag)
+			fmt.Printf("  %2d. %s\n", len(choices), tag)
+		}
+	}
+
+	commits, _ := gm.runGitCommand("log", "--format=%h%x1f%s%x1f%ar", "-15")
+	fmt.Printf("%s📈 Commits récents:%s\n", ColorBlue, ColorReset)
+	for _, line := range strings.Split(commits, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) < 3 {
+			continue
+		}
+		choices = append(choices, fields[0])
+		fmt.Printf("  %2d. %s%s%s %s %s(%s)%s\n", len(choices), ColorYellow, fields[0], ColorReset, fields[1], ColorGreen, fields[2], ColorReset)
+	}
+
+	fmt.Printf("\n%s%s (numéro ou révision, défaut: %s): %s", ColorYellow, prompt, defaultRev, ColorReset)
+	choice := gm.getUserInput()
+	if choice == "" {
+		choice = defaultRev
+	} else if i, err := strconv.Atoi(choice); err == nil && i >= 1 && i <= len(choices) {
+		choice = choices[i-1]
+	}
+
+	if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", choice+"^{commit}"); err != nil {
+		fmt.Printf("%s❌ Révision introuvable: %s%s\n", ColorRed, choice, ColorReset)
+		return ""
+	}
+	return choice
+}
+
+func (gm *GitManager) handleBisect() {
+	if !gm.bisectInProgress() {
+		if !gm.startBisect() {
 			gm.pause()
+			return
+		}
+	}
+	gm.bisectSession()
+}
 
-		case "3":
-			fmt.Printf("%sNom du fichier: %s", ColorYellow, ColorReset)
-			filename := gm.getUserInput()
-			if filename != "" {
-				output, err := gm.runGitCommand("log", "--follow", "--oneline", "--", filename)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-				} else {
-					fmt.Printf("%s📊 Historique de '%s':%s\n", ColorGreen, filename, ColorReset)
-					fmt.Println(output)
-				}
+func (gm *GitManager) startBisect() bool {
+	if operation := gm.getOperationInProgress(); operation != "" {
+		fmt.Printf("%s❌ Un %s est déjà en cours.%s\n", ColorRed, operation, ColorReset)
+		return false
+	}
+	if gm.getGitStatus() != "" {
+		fmt.Printf("%s⚠️  Des modifications locales sont présentes: le bisect va changer de commit.%s\n", ColorYellow, ColorReset)
+		fmt.Printf("%sContinuer quand même? (y/N): %s", ColorYellow, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
+			return false
+		}
+	}
+
+	gm.clearScreen()
+	fmt.Printf("%s%s🔎 BISECT: MAUVAISE RÉVISION (bug présent)%s\n", ColorBold, ColorRed, ColorReset)
+	bad := gm.pickRevision("Révision mauvaise", "HEAD")
+	if bad == "" {
+		return false
+	}
+
+	gm.clearScreen()
+	fmt.Printf("%s%s🔎 BISECT: BONNE RÉVISION (bug absent)%s\n", ColorBold, ColorGreen, ColorReset)
+	defaultGood := ""
+	if tag, err := gm.runGitCommand("describe", "--tags", "--abbrev=0", bad+"^"); err == nil {
+		defaultGood = tag
+	}
+	good := gm.pickRevision("Révision bonne", defaultGood)
+	if good == "" {
+		return false
+	}
+	if _, err := gm.runGitCommand("merge-base", "--is-ancestor", good, bad); err != nil {
+		fmt.Printf("%s❌ La bonne révision doit être un ancêtre de la mauvaise.%s\n", ColorRed, ColorReset)
+		return false
+	}
+
+	output, err := gm.runGitCommand("bisect", "start", bad, good)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		return false
+	}
+	return true
+}
+
+// Session guidée: good / bad / skip jusqu'au premier commit fautif
+func (gm *GitManager) bisectSession() {
+	for {
+		if firstBad := gm.bisectFirstBad(); firstBad != "" {
+			gm.finishBisect(firstBad)
+			return
+		}
+
+		gm.clearScreen()
+		fmt.Printf("%s%s🔎 BISECT EN COURS%s\n", ColorBold, ColorPurple, ColorReset)
+		fmt.Println(strings.Repeat("═", 40))
+
+		current, _ := gm.runGitCommand("log", "-1", "--format=%h %s (%ar) <%an>", "HEAD")
+		candidates, steps := gm.bisectProgress()
+		fmt.Printf("%sCommit à tester:%s %s\n", ColorBlue, ColorReset, current)
+		fmt.Printf("%sCandidats restants:%s %d   %sÉtapes estimées:%s ~%d\n", ColorBlue, ColorReset, candidates, ColorBlue, ColorReset, steps+1)
+
+		fmt.Printf("\n%sg%s bon   %sb%s mauvais   %ss%s passer (non testable)\n", ColorGreen, ColorReset, ColorRed, ColorReset, ColorYellow, ColorReset)
+		fmt.Printf("%sr%s lancer une commande de test (bisect run)   %sv%s commits restants   %sl%s journal\n", ColorCyan, ColorReset, ColorBlue, ColorReset, ColorBlue, ColorReset)
+		fmt.Printf("%sq%s mettre en pause   %sx%s abandonner (bisect reset)\n", ColorWhite, ColorReset, ColorRed, ColorReset)
+		fmt.Printf("\n%sRésultat du test: %s", ColorYellow, ColorReset)
+
+		marks := map[string]string{"g": "good", "b": "bad", "s": "skip"}
+		choice := strings.ToLower(gm.getUserInput())
+		if mark, ok := marks[choice]; ok {
+			if output, err := gm.runGitCommand("bisect", mark); err != nil {
+				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+				gm.pause()
+			} else if strings.Contains(output, "only skipped commits left") {
+				fmt.Printf("%s⚠️  Il ne reste que des commits passés: le premier commit fautif ne peut pas être déterminé.%s\n", ColorYellow, ColorReset)
+				fmt.Println(output)
+				gm.pause()
 			}
+			continue
+		}
+
+		switch choice {
+		case "r":
+			gm.runBisectCommand()
+		case "v":
+			remaining, _ := gm.runGitCommand("bisect", "visualize", "--oneline")
+			fmt.Printf("%s📋 Commits candidats:%s\n%s\n", ColorBlue, ColorReset, remaining)
+			gm.pause()
+		case "l":
+			log, _ := gm.runGitCommand("bisect", "log")
+			fmt.Println(log)
+			gm.pause()
+		case "q":
+			fmt.Printf("%s⏸️  Bisect en pause: revenez dans ce menu pour reprendre.%s\n", ColorYellow, ColorReset)
 			gm.pause()
-		case "0":
 			return
-		default:
-			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+		case "x":
+			gm.saveBisectLog()
+			gm.runGitCommand("bisect", "reset")
+			fmt.Printf("%s✅ Bisect abandonné, retour sur %s%s\n", ColorGreen, gm.getCurrentBranch(), ColorReset)
 			gm.pause()
+			return
 		}
 	}
 }
 
-func (gm *GitManager) showBlame() {
-	fmt.Printf("%sNom du fichier à analyser: %s", ColorYellow, ColorReset)
-	filename := gm.getUserInput()
+// Automatise le bisect avec une commande de test (code 0 = bon, 125 = passer, autre = mauvais)
+func (gm *GitManager) runBisectCommand() {
+	fmt.Printf("%sCommande de test (ex: go test ./..., make check): %s", ColorYellow, ColorReset)
+	command := gm.getUserInput()
+	if command == "" {
+		return
+	}
+	fmt.Printf("%s💡 Code de sortie 0 = bon, 125 = non testable, autre = mauvais%s\n\n", ColorCyan, ColorReset)
 
-	if filename == "" {
+	if err := gm.runGitCommandInteractive(nil, "bisect", "run", "sh", "-c", command); err != nil && gm.bisectFirstBad() == "" {
+		fmt.Printf("%s❌ bisect run s'est arrêté: %v%s\n", ColorRed, err, ColorReset)
+	}
+	gm.pause()
+}
+
+// Résumé final: premier commit fautif, journal sauvegardé et retour à la branche d'origine
+func (gm *GitManager) finishBisect(firstBad string) {
+	gm.clearScreen()
+	fmt.Printf("%s%s🎯 PREMIER COMMIT FAUTIF TROUVÉ%s\n", ColorBold, ColorGreen, ColorReset)
+	fmt.Println(strings.Repeat("═", 40))
+
+	summary, _ := gm.runGitCommand("show", "--stat", "--color=always", "--format=%C(yellow)%H%C(reset)%n%s%nAuteur: %an <%ae>%nDate:   %ad%n", firstBad)
+	fmt.Println(summary)
+
+	if tags, _ := gm.runGitCommand("tag", "--contains", firstBad, "--sort=version:refname"); tags != "" {
+		fmt.Printf("\n%sPremière version concernée:%s %s\n", ColorBlue, ColorReset, strings.Split(tags, "\n")[0])
+	}
+
+	gm.saveBisectLog()
+
+	fmt.Printf("\n%sTerminer le bisect et revenir à la branche d'origine? (y/N): %s", ColorYellow, ColorReset)
+	if strings.ToLower(gm.getUserInput()) == "y" {
+		gm.runGitCommand("bisect", "reset")
+		fmt.Printf("%s✅ Bisect terminé, retour sur %s%s\n", ColorGreen, gm.getCurrentBranch(), ColorReset)
+	} else {
+		fmt.Printf("%s💡 Bisect toujours actif: terminez-le via Outils > Bisect%s\n", ColorCyan, ColorReset)
+	}
+	gm.pause()
+}
+
+// Enregistre le journal du bisect (rejouable avec git bisect replay)
+func (gm *GitManager) saveBisectLog() {
+	log, err := gm.runGitCommand("bisect", "log")
+	if err != nil || log == "" {
 		return
 	}
 
-	if _, err := os.Stat(filepath.Join(gm.currentPath, filename)); os.IsNotExist(err) {
-		fmt.Printf("%s❌ Fichier '%s' introuvable!%s\n", ColorRed, filename, ColorReset)
-		gm.pause()
+	defaultPath := filepath.Join(gm.currentPath, fmt.Sprintf("bisect-%s.log", time.Now().Format("20060102-150405")))
+	fmt.Printf("\n%sEnregistrer le journal du bisect (chemin, défaut: %s, '-' pour ignorer): %s", ColorYellow, defaultPath, ColorReset)
+	path := gm.getUserInput()
+	if path == "-" {
+		return
+	}
+	if path == "" {
+		path = defaultPath
+	}
+
+	if err := os.WriteFile(path, []byte(log+"\n"), 0644); err != nil {
+		fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
 		return
 	}
+	fmt.Printf("%s✅ Journal enregistré: %s%s\n", ColorGreen, path, ColorReset)
+	fmt.Printf("%s💡 Rejouable avec: git bisect replay %s%s\n", ColorCyan, filepath.Base(path), ColorReset)
+}
 
+// Reflog
+// Entrée du reflog ou commit perdu proposé à la récupération
+type recoveryEntry struct {
+	hash    string
+	when    string
+	action  string
+	subject string
+}
+
+func (gm *GitManager) handleReflog() {
 	for {
 		gm.clearScreen()
-		fmt.Println("1. Blâme complet")
-		fmt.Println("2. Blâme avec statistiques")
-		fmt.Println("3. Blâme d'une plage de lignes")
+		fmt.Printf("%s%s🕰️  REFLOG ET RÉCUPÉRATION%s\n", ColorBold, ColorPurple, ColorReset)
+		fmt.Println(strings.Repeat("═", 35))
+		fmt.Println("1. Reflog de HEAD")
+		fmt.Println("2. Reflog d'une branche")
+		fmt.Println("3. Chercher les commits perdus (fsck --lost-found)")
 		fmt.Println("0. Retour")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
-
-		var output string
-		var err error
-
-		switch choice {
+		switch gm.getUserInput() {
 		case "1":
-			output, err = gm.runGitCommand("blame", filename)
+			gm.browseReflog("HEAD")
 		case "2":
-			output, err = gm.runGitCommand("blame", "-w", "-C", "-C", "-C", filename)
-		case "3":
-			fmt.Printf("%sLigne de début: %s", ColorYellow, ColorReset)
-			startLine := gm.getUserInput()
-			fmt.Printf("%sLigne de fin: %s", ColorYellow, ColorReset)
-			endLine := gm.getUserInput()
-
-			if startLine != "" && endLine != "" {
-				lineRange := fmt.Sprintf("-L%s,%s", startLine, endLine)
-				output, err = gm.runGitCommand("blame", lineRange, filename)
+			branches, _ := gm.runGitCommand("branch", "--format=%(refname:short)")
+			fmt.Println(branches)
+			fmt.Printf("\n%sBranche (défaut: %s): %s", ColorYellow, gm.getCurrentBranch(), ColorReset)
+			branch := gm.getUserInput()
+			if branch == "" {
+				branch = gm.getCurrentBranch()
 			}
+			gm.browseReflog(branch)
+		case "3":
+			gm.browseLostCommits()
 		case "0":
 			return
 		default:
 			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
 			gm.pause()
+		}
+	}
+}
+
+func (gm *GitManager) browseReflog(ref string) {
+	output, err := gm.runGitCommand("reflog", "show", "-n", "40", "--date=format:%Y-%m-%d %H:%M", "--format=%h%x1f%gd%x1f%gs%x1f%s", ref)
+	if err != nil || output == "" {
+		fmt.Printf("%s❌ Aucun reflog pour '%s': %s%s\n", ColorRed, ref, output, ColorReset)
+		gm.pause()
+		return
+	}
+
+	var entries []recoveryEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) < 4 {
 			continue
 		}
+		// %gd vaut "ref@{date}" avec --date
+		when := fields[1]
+		if start := strings.Index(when, "@{"); start >= 0 {
+			when = strings.TrimSuffix(when[start+2:], "}")
+		}
+		entries = append(entries, recoveryEntry{hash: fields[0], when: when, action: fields[2], subject: fields[3]})
+	}
 
-		if err != nil {
-			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else {
-			fmt.Printf("%s🔍 Blâme de '%s':%s\n", ColorBlue, filename, ColorReset)
-			fmt.Println(output)
+	gm.recoveryBrowser(fmt.Sprintf("REFLOG DE %s", ref), entries)
+}
+
+// Commits inaccessibles (reset, branche supprimée, stash abandonné) trouvés par git fsck
+func (gm *GitManager) browseLostCommits() {
+	fmt.Printf("%s🔍 Recherche des commits perdus...%s\n", ColorBlue, ColorReset)
+	output, _ := gm.runGitCommand("fsck", "--lost-found")
+
+	email := gm.getGitConfig("user.email")
+	var entries []recoveryEntry
+	var timestamps = make(map[string]int64)
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "dangling commit ") {
+			continue
+		}
+		hash := strings.TrimPrefix(line, "dangling commit ")
+		info, err := gm.runGitCommand("log", "-1", "--date=format:%Y-%m-%d %H:%M", "--format=%h%x1f%ad%x1f%ae%x1f%s%x1f%at", hash)
+		fields := strings.Split(info, "\x1f")
+		if err != nil || len(fields) < 5 {
+			continue
+		}
+
+		action := "commit perdu"
+		if fields[2] == email {
+			action = "commit perdu (vous)"
+		}
+		if gm.isStashCommit(hash) {
+			action = "stash abandonné"
 		}
+		timestamps[fields[0]], _ = strconv.ParseInt(fields[4], 10, 64)
+		entries = append(entries, recoveryEntry{hash: fields[0], when: fields[1], action: action, subject: fields[3]})
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("%s✅ Aucun commit perdu trouvé%s\n", ColorGreen, ColorReset)
 		gm.pause()
+		return
 	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return timestamps[entries[i].hash] > timestamps[entries[j].hash]
+	})
+	gm.recoveryBrowser("COMMITS PERDUS", entries)
 }
 
-func (gm *GitManager) searchHistory() {
+// Liste navigable avec récupération en une touche: branche, reset, cherry-pick ou détails
+func (gm *GitManager) recoveryBrowser(title string, entries []recoveryEntry) {
 	for {
 		gm.clearScreen()
-		fmt.Println("1. Rechercher dans les messages de commit")
-		fmt.Println("2. Rechercher dans le code (diff)")
-		fmt.Println("3. Rechercher par auteur")
-		fmt.Println("4. Rechercher par date")
-		fmt.Println("0. Retour")
-
-		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
-
-		var output string
-		var err error
-		var args []string
+		fmt.Printf("%s%s🕰️  %s%s\n", ColorBold, ColorPurple, title, ColorReset)
+		fmt.Println(strings.Repeat("═", 60))
+		for i, entry := range entries {
+			fmt.Printf("%3d. %s%s%s %s%s%s %s%-28s%s %s\n", i+1, ColorYellow, entry.hash, ColorReset, ColorGreen, entry.when, ColorReset,
+				ColorCyan, entry.action, ColorReset, entry.subject)
+		}
 
-		switch choice {
-		case "1":
-			fmt.Printf("%sTerme à rechercher: %s", ColorYellow, ColorReset)
-			term := gm.getUserInput()
-			if term != "" {
-				args = []string{"log", "--grep=" + term, "--oneline"}
-				output, err = gm.runGitCommand(args...)
-				fmt.Printf("%s🔍 Commits contenant '%s':%s\n", ColorBlue, term, ColorReset)
-			}
-		case "2":
-			fmt.Printf("%sCode à rechercher: %s", ColorYellow, ColorReset)
-			code := gm.getUserInput()
-			if code != "" {
-				args = []string{"log", "-S" + code, "--oneline"}
-				output, err = gm.runGitCommand(args...)
-				fmt.Printf("%s🔍 Commits modifiant '%s':%s\n", ColorBlue, code, ColorReset)
-			}
-		case "3":
-			fmt.Printf("%sAuteur à rechercher: %s", ColorYellow, ColorReset)
-			author := gm.getUserInput()
-			if author != "" {
-				args = []string{"log", "--author=" + author, "--oneline"}
-				output, err = gm.runGitCommand(args...)
-				fmt.Printf("%s🔍 Commits de '%s':%s\n", ColorBlue, author, ColorReset)
-			}
-		case "4":
-			fmt.Printf("%sDate de début (YYYY-MM-DD): %s", ColorYellow, ColorReset)
-			since := gm.getUserInput()
-			fmt.Printf("%sDate de fin (YYYY-MM-DD, optionnel): %s", ColorYellow, ColorReset)
-			until := gm.getUserInput()
+		fmt.Printf("\n%sb <n>%s créer une branche  %sr <n>%s reset --hard  %sc <n>%s cherry-pick  %ss <n>%s détails  %sq%s retour\n",
+			ColorGreen, ColorReset, ColorRed, ColorReset, ColorCyan, ColorReset, ColorBlue, ColorReset, ColorWhite, ColorReset)
+		fmt.Printf("%sAction: %s", ColorYellow, ColorReset)
 
-			if since != "" {
-				args = []string{"log", "--oneline", "--since=" + since}
-				if until != "" {
-					args = append(args, "--until="+until)
-				}
-				output, err = gm.runGitCommand(args...)
-				fmt.Printf("%s🔍 Commits depuis %s", ColorBlue, since)
-				if until != "" {
-					fmt.Printf(" jusqu'à %s", until)
-				}
-				fmt.Printf(":%s\n", ColorReset)
-			}
-		case "0":
+		fields := strings.Fields(gm.getUserInput())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "q" || fields[0] == "0" {
 			return
-		default:
-			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[1])
+		if err != nil || index < 1 || index > len(entries) {
+			fmt.Printf("%s❌ Numéro invalide!%s\n", ColorRed, ColorReset)
 			gm.pause()
 			continue
 		}
+		gm.recoverCommit(fields[0], entries[index-1].hash)
+	}
+}
 
-		if err != nil {
+// Un stash est un merge "WIP on/On <branche>: ..." dont le second parent est le commit "index on ..."
+func (gm *GitManager) isStashCommit(hash string) bool {
+	subject, _ := gm.runGitCommand("log", "-1", "--format=%s", hash)
+	if !stashSubject.MatchString(subject) {
+		return false
+	}
+	indexSubject, err := gm.runGitCommand("log", "-1", "--format=%s", hash+"^2")
+	return err == nil && strings.HasPrefix(indexSubject, "index on ")
+}
+
+func (gm *GitManager) recoverCommit(action, hash string) {
+	switch action {
+	case "b":
+		name := gm.promptBranchName("Nom de la nouvelle branche")
+		if name == "" {
+			fmt.Printf("%s❌ Nom invalide!%s\n", ColorRed, ColorReset)
+			break
+		}
+		if output, err := gm.runGitCommand("branch", name, hash); err != nil {
 			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else if output == "" {
-			fmt.Printf("%s❌ Aucun résultat%s\n", ColorRed, ColorReset)
 		} else {
-			fmt.Println(output)
+			fmt.Printf("%s✅ Branche '%s' créée sur %s%s\n", ColorGreen, name, hash, ColorReset)
 		}
-		gm.pause()
+	case "r":
+		branch := gm.getCurrentBranch()
+		if gm.getGitStatus() != "" {
+			fmt.Printf("%s⚠️  Les modifications non commitées seront perdues!%s\n", ColorRed, ColorReset)
+		}
+		leaving, _ := gm.runGitCommand("log", "--oneline", hash+"..HEAD")
+		if leaving != "" {
+			fmt.Printf("%s⚠️  Commits qui quitteront '%s' (récupérables via ce reflog):%s\n%s\n", ColorYellow, branch, ColorReset, leaving)
+		}
+		fmt.Printf("%sReset --hard de '%s' sur %s? (y/N): %s", ColorRed, branch, hash, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" || !gm.confirmProtectedAction(branch, "reset --hard") {
+			break
+		}
+		if output, err := gm.runGitCommand("reset", "--hard", hash); err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ %s%s\n", ColorGreen, output, ColorReset)
+		}
+	case "c":
+		args := []string{"cherry-pick", hash}
+		if gm.isStashCommit(hash) {
+			// Un stash abandonné est un commit de merge: on réapplique son contenu
+			args = []string{"stash", "apply", hash}
+		} else if parents, _ := gm.runGitCommand("rev-list", "--parents", "-n", "1", hash); len(strings.Fields(parents)) > 2 {
+			// Merge ordinaire: ses changements sont rejoués par rapport à son premier parent
+			fmt.Printf("%s💡 Commit de merge: ses changements sont appliqués par rapport à son premier parent (-m 1)%s\n", ColorCyan, ColorReset)
+			args = []string{"cherry-pick", "-m", "1", hash}
+		}
+		if output, err := gm.runGitCommand(args...); err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			if gm.getOperationInProgress() != "" {
+				gm.pause()
+				gm.handleOperationInProgress()
+				return
+			}
+		} else {
+			fmt.Printf("%s✅ Commit %s appliqué sur %s%s\n", ColorGreen, hash, gm.getCurrentBranch(), ColorReset)
+		}
+	case "s":
+		output, _ := gm.runGitCommand("show", "--stat", "--color=always", hash)
+		fmt.Println(output)
+	default:
+		fmt.Printf("%s❌ Action inconnue: %s%s\n", ColorRed, action, ColorReset)
+	}
+	gm.pause()
+}
+
+// Worktrees
+type worktreeInfo struct {
+	path     string
+	head     string
+	branch   string
+	detached bool
+	bare     bool
+	locked   bool
+	prunable bool
+}
+
+func (gm *GitManager) listWorktrees() []worktreeInfo {
+	output, err := gm.runGitCommand("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil
+	}
+
+	var worktrees []worktreeInfo
+	var current *worktreeInfo
+	for _, line := range strings.Split(output, "\n") {
+		field, value, _ := strings.Cut(line, " ")
+		switch field {
+		case "worktree":
+			worktrees = append(worktrees, worktreeInfo{path: value})
+			current = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			if current != nil && len(value) >= 7 {
+				current.head = value[:7]
+			}
+		case "branch":
+			if current != nil {
+				current.branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "detached":
+			if current != nil {
+				current.detached = true
+			}
+		case "bare":
+			if current != nil {
+				current.bare = true
+			}
+		case "locked":
+			if current != nil {
+				current.locked = true
+			}
+		case "prunable":
+			if current != nil {
+				current.prunable = true
+			}
+		}
+	}
+	return worktrees
+}
+
+func (gm *GitManager) printWorktrees(worktrees []worktreeInfo) {
+	for i, wt := range worktrees {
+		marker := " "
+		if filepath.Clean(wt.path) == filepath.Clean(gm.currentPath) {
+			marker = "*"
+		}
+
+		branch := wt.branch
+		if wt.detached {
+			branch = "(HEAD détachée)"
+		} else if wt.bare {
+			branch = "(bare)"
+		}
+
+		state := ""
+		switch {
+		case wt.prunable:
+			state = ColorRed + "introuvable (prunable)" + ColorReset
+		case wt.bare:
+			state = ""
+		default:
+			if status, err := gm.runGitCommandIn(wt.path, "status", "--porcelain"); err != nil {
+				state = ColorRed + "inaccessible" + ColorReset
+			} else if status != "" {
+				state = fmt.Sprintf("%s⚠ %d modification(s)%s", ColorYellow, len(strings.Split(status, "\n")), ColorReset)
+			} else {
+				state = ColorGreen + "✓ propre" + ColorReset
+			}
+		}
+		if wt.locked {
+			state += " 🔒"
+		}
+
+		fmt.Printf(" %s%2d. %s%-25s%s %s%s%s %s\n      %s\n", marker, i+1, ColorCyan, branch, ColorReset, ColorYellow, wt.head, ColorReset, state, wt.path)
+	}
+}
+
+// Sélectionne un worktree par numéro dans la liste affichée
+func (gm *GitManager) selectWorktree(worktrees []worktreeInfo, prompt string) *worktreeInfo {
+	fmt.Printf("%s%s: %s", ColorYellow, prompt, ColorReset)
+	n, err := strconv.Atoi(gm.getUserInput())
+	if err != nil || n < 1 || n > len(worktrees) {
+		fmt.Printf("%s❌ Numéro invalide!%s\n", ColorRed, ColorReset)
+		return nil
 	}
+	return &worktrees[n-1]
 }
 
-// Tools
-func (gm *GitManager) manageConfig() {
+func (gm *GitManager) handleWorktrees() {
+	if !gm.isGitRepo() {
+		fmt.Printf("%s❌ Ce répertoire n'est pas un dépôt Git!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
 	for {
 		gm.clearScreen()
-		fmt.Println("1. Voir la configuration")
-		fmt.Println("2. Configurer utilisateur")
-		fmt.Println("3. Configurer email")
-		fmt.Println("4. Autres configurations")
-		fmt.Println("0. Retour")
+		fmt.Printf("%s%s🌲 GESTION DES WORKTREES%s\n", ColorBold, ColorGreen, ColorReset)
+		fmt.Println(strings.Repeat("═", 30))
+
+		worktrees := gm.listWorktrees()
+		gm.printWorktrees(worktrees)
+
+		fmt.Println("\n1. Ajouter un worktree (branche existante)")
+		fmt.Println("2. Ajouter un worktree avec une nouvelle branche")
+		fmt.Println("3. Aller dans un worktree")
+		fmt.Println("4. Supprimer un worktree")
+		fmt.Println("5. Nettoyer les worktrees disparus (prune)")
+		fmt.Println("0. Retour au menu principal")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
 		choice := gm.getUserInput()
 
 		switch choice {
-		case "1":
-			output, err := gm.runGitCommand("config", "--list")
-			if err != nil {
-				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-			} else {
-				fmt.Printf("%s⚙️  Configuration actuelle:%s\n", ColorBlue, ColorReset)
-				fmt.Println(output)
-			}
-			gm.pause()
-		case "2":
-			fmt.Printf("%sNom d'utilisateur: %s", ColorYellow, ColorReset)
-			username := gm.getUserInput()
-			if username != "" {
-				output, err := gm.runGitCommand("config", "user.name", username)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-				} else {
-					fmt.Printf("%s✅ Nom d'utilisateur configuré!%s\n", ColorGreen, ColorReset)
-				}
-			}
-			gm.pause()
+		case "1", "2":
+			gm.addWorktree(choice == "2")
 		case "3":
-			fmt.Printf("%sEmail: %s", ColorYellow, ColorReset)
-			email := gm.getUserInput()
-			if email != "" {
-				output, err := gm.runGitCommand("config", "user.email", email)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+			if wt := gm.selectWorktree(worktrees, "Worktree à ouvrir"); wt != nil {
+				if wt.prunable || wt.bare {
+					fmt.Printf("%s❌ Ce worktree n'est pas utilisable.%s\n", ColorRed, ColorReset)
+				} else if err := os.Chdir(wt.path); err != nil {
+					fmt.Printf("%s❌ Erreur: %v%s\n", ColorRed, err, ColorReset)
 				} else {
-					fmt.Printf("%s✅ Email configuré!%s\n", ColorGreen, ColorReset)
+					gm.currentPath = wt.path
+					fmt.Printf("%s✅ Répertoire changé pour: %s%s\n", ColorGreen, gm.currentPath, ColorReset)
 				}
 			}
 			gm.pause()
 		case "4":
-			fmt.Println("Configurations rapides:")
-			fmt.Println("a. Activer la couleur")
-			fmt.Println("b. Configurer l'éditeur par défaut")
-			fmt.Println("c. Configurer le push par défaut")
-
-			fmt.Printf("\n%sChoisissez: %s", ColorYellow, ColorReset)
-			subChoice := gm.getUserInput()
-
-			switch subChoice {
-			case "a":
-				gm.runGitCommand("config", "color.ui", "auto")
-				fmt.Printf("%s✅ Couleur activée!%s\n", ColorGreen, ColorReset)
-			case "b":
-				fmt.Printf("%sÉditeur (nano, vim, code, etc.): %s", ColorYellow, ColorReset)
-				editor := gm.getUserInput()
-				if editor != "" {
-					gm.runGitCommand("config", "core.editor", editor)
-					fmt.Printf("%s✅ Éditeur configuré!%s\n", ColorGreen, ColorReset)
+			gm.removeWorktree(worktrees)
+		case "5":
+			preview, _ := gm.runGitCommand("worktree", "prune", "--dry-run", "--verbose")
+			if preview == "" {
+				fmt.Printf("%s✅ Aucun worktree à nettoyer!%s\n", ColorGreen, ColorReset)
+			} else {
+				fmt.Println(preview)
+				fmt.Printf("\n%sContinuer? (y/N): %s", ColorYellow, ColorReset)
+				if strings.ToLower(gm.getUserInput()) == "y" {
+					output, err := gm.runGitCommand("worktree", "prune", "--verbose")
+					if err != nil {
+						fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+					} else {
+						fmt.Printf("%s✅ Worktrees nettoyés!%s\n", ColorGreen, ColorReset)
+					}
 				}
-			case "c":
-				gm.runGitCommand("config", "push.default", "simple")
-				fmt.Printf("%s✅ Push par défaut configuré!%s\n", ColorGreen, ColorReset)
 			}
 			gm.pause()
 		case "0":
@@ -2931,109 +8974,372 @@ func (gm *GitManager) manageConfig() {
 	}
 }
 
-func (gm *GitManager) cleanRepo() {
-	for {
-		gm.clearScreen()
-		fmt.Printf("%s🧹 NETTOYAGE DU DÉPÔT%s\n", ColorYellow, ColorReset)
-		fmt.Println(strings.Repeat("═", 25))
+func (gm *GitManager) addWorktree(newBranch bool) {
+	var branch, base string
+	if newBranch {
+		branch = gm.promptBranchName("Nom de la nouvelle branche")
+		if branch == "" {
+			gm.pause()
+			return
+		}
+		fmt.Printf("%sPoint de départ (défaut HEAD): %s", ColorYellow, ColorReset)
+		base = gm.getUserInput()
+		if base == "" {
+			base = "HEAD"
+		}
+	} else {
+		branches, _ := gm.runGitCommand("branch", "--format=%(refname:short)")
+		fmt.Printf("%sBranches locales:%s\n", ColorBlue, ColorReset)
+		fmt.Println(branches)
+		fmt.Printf("\n%sBranche à extraire: %s", ColorYellow, ColorReset)
+		branch = gm.getUserInput()
+		if branch == "" {
+			fmt.Printf("%s❌ Nom de branche invalide!%s\n", ColorRed, ColorReset)
+			gm.pause()
+			return
+		}
+	}
 
-		fmt.Println("1. Nettoyer les fichiers non trackés (clean)")
-		fmt.Println("2. Nettoyer les objets inaccessibles (prune)")
-		fmt.Println("3. Optimiser le dépôt (gc)")
-		fmt.Println("4. Nettoyage complet")
-		fmt.Println("0. Retour")
+	// Par défaut: répertoire voisin nommé d'après le dépôt et la branche
+	topLevel, err := gm.runGitCommand("rev-parse", "--show-toplevel")
+	if err != nil {
+		topLevel = gm.currentPath
+	}
+	defaultPath := filepath.Join(filepath.Dir(topLevel), filepath.Base(topLevel)+"-"+slugify(branch))
+	fmt.Printf("%sChemin du worktree (défaut '%s'): %s", ColorYellow, defaultPath, ColorReset)
+	path := gm.getUserInput()
+	if path == "" {
+		path = defaultPath
+	}
 
-		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
+	args := []string{"worktree", "add"}
+	if newBranch {
+		args = append(args, "-b", branch, path, base)
+	} else {
+		args = append(args, path, branch)
+	}
 
-		switch choice {
-		case "1":
-			preview, _ := gm.runGitCommand("clean", "-n")
-			if preview != "" {
-				fmt.Printf("%sFichiers qui seront supprimés:%s\n", ColorRed, ColorReset)
-				fmt.Println(preview)
+	output, err := gm.runGitCommand(args...)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		gm.pause()
+		return
+	}
+	fmt.Printf("%s✅ Worktree créé dans '%s' sur la branche '%s'!%s\n", ColorGreen, path, branch, ColorReset)
 
-				fmt.Printf("\n%sContinuer? (y/N): %s", ColorYellow, ColorReset)
-				confirm := gm.getUserInput()
-				if strings.ToLower(confirm) == "y" {
-					output, err := gm.runGitCommand("clean", "-f")
-					if err != nil {
-						fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-					} else {
-						fmt.Printf("%s✅ Fichiers non trackés supprimés!%s\n", ColorGreen, ColorReset)
-					}
-				}
-			} else {
-				fmt.Printf("%s✅ Aucun fichier à nettoyer!%s\n", ColorGreen, ColorReset)
-			}
-			gm.pause()
-		case "2":
-			fmt.Printf("%sNettoyage des objets inaccessibles...%s\n", ColorYellow, ColorReset)
-			output, err := gm.runGitCommand("gc", "--prune=now")
-			if err != nil {
-				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-			} else {
-				fmt.Printf("%s✅ Objets inaccessibles nettoyés!%s\n", ColorGreen, ColorReset)
-			}
-			gm.pause()
-		case "3":
-			fmt.Printf("%sOptimisation du dépôt...%s\n", ColorYellow, ColorReset)
-			output, err := gm.runGitCommand("gc", "--aggressive", "--prune=now")
-			if err != nil {
-				fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-			} else {
-				fmt.Printf("%s✅ Dépôt optimisé!%s\n", ColorGreen, ColorReset)
-			}
-			gm.pause()
-		case "4":
-			fmt.Printf("%s⚠️  Nettoyage complet (peut prendre du temps). Continuer? (y/N): %s", ColorRed, ColorReset)
-			confirm := gm.getUserInput()
-			if strings.ToLower(confirm) == "y" {
-				fmt.Printf("%sNettoyage en cours...%s\n", ColorYellow, ColorReset)
-				gm.runGitCommand("clean", "-f", "-d")
-				gm.runGitCommand("gc", "--aggressive", "--prune=now")
-				gm.runGitCommand("reflog", "expire", "--expire=now", "--all")
-				fmt.Printf("%s✅ Nettoyage complet terminé!%s\n", ColorGreen, ColorReset)
-			}
+	fmt.Printf("%sOuvrir ce worktree maintenant? (y/N): %s", ColorYellow, ColorReset)
+	if strings.ToLower(gm.getUserInput()) == "y" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(gm.currentPath, path)
+		}
+		if err := os.Chdir(path); err == nil {
+			gm.currentPath, _ = os.Getwd()
+			fmt.Printf("%s✅ Répertoire changé pour: %s%s\n", ColorGreen, gm.currentPath, ColorReset)
+		}
+	}
+	gm.pause()
+}
+
+func (gm *GitManager) removeWorktree(worktrees []worktreeInfo) {
+	wt := gm.selectWorktree(worktrees, "Worktree à supprimer")
+	if wt == nil {
+		gm.pause()
+		return
+	}
+	if wt == &worktrees[0] {
+		fmt.Printf("%s❌ Le worktree principal ne peut pas être supprimé.%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+	if filepath.Clean(wt.path) == filepath.Clean(gm.currentPath) {
+		fmt.Printf("%s❌ Quittez d'abord ce worktree (option 3).%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
+	args := []string{"worktree", "remove", wt.path}
+	if status, _ := gm.runGitCommandIn(wt.path, "status", "--porcelain"); status != "" {
+		fmt.Printf("%s⚠️  Ce worktree contient des modifications non commitées:%s\n", ColorRed, ColorReset)
+		fmt.Println(status)
+		fmt.Printf("%sSupprimer quand même (--force)? (y/N): %s", ColorRed, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
 			gm.pause()
-		case "0":
 			return
-		default:
-			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+		}
+		args = append(args, "--force")
+	} else {
+		fmt.Printf("%s⚠️  Supprimer le worktree '%s'? (y/N): %s", ColorRed, wt.path, ColorReset)
+		if strings.ToLower(gm.getUserInput()) != "y" {
 			gm.pause()
+			return
+		}
+	}
+
+	output, err := gm.runGitCommand(args...)
+	if err != nil {
+		fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+	} else {
+		fmt.Printf("%s✅ Worktree supprimé (la branche '%s' est conservée)!%s\n", ColorGreen, wt.branch, ColorReset)
+	}
+	gm.pause()
+}
+
+// Workflow (feature / release / hotfix)
+
+// Paramètres du workflow, lus depuis la section gitman.flow de la configuration Git
+type flowConfig struct {
+	mainBranch    string
+	developBranch string
+	featurePrefix string
+	releasePrefix string
+	hotfixPrefix  string
+	tagPrefix     string
+	finishMode    string // merge ou rebase
+}
+
+// Transaction de workflow: mémorise l'état initial pour annuler un flow interrompu
+type flowTransaction struct {
+	gm              *GitManager
+	originalBranch  string
+	refs            map[string]string // branche -> commit avant le flow
+	createdTags     []string
+	createdBranches []string
+}
+
+func (gm *GitManager) loadFlowConfig() flowConfig {
+	cfg := flowConfig{
+		mainBranch:    gm.getGitConfig("gitman.flow.main"),
+		developBranch: gm.getGitConfig("gitman.flow.develop"),
+		featurePrefix: gm.getGitConfig("gitman.flow.featurePrefix"),
+		releasePrefix: gm.getGitConfig("gitman.flow.releasePrefix"),
+		hotfixPrefix:  gm.getGitConfig("gitman.flow.hotfixPrefix"),
+		tagPrefix:     gm.getGitConfig("gitman.flow.tagPrefix"),
+		finishMode:    gm.getGitConfig("gitman.flow.finishMode"),
+	}
+
+	if cfg.mainBranch == "" {
+		cfg.mainBranch = "main"
+		if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/heads/main"); err != nil {
+			if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/heads/master"); err == nil {
+				cfg.mainBranch = "master"
+			}
+		}
+	}
+	if cfg.developBranch == "" {
+		// Sans branche develop, on travaille en trunk-based sur la branche principale
+		cfg.developBranch = cfg.mainBranch
+		if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/heads/develop"); err == nil {
+			cfg.developBranch = "develop"
+		}
+	}
+	if cfg.featurePrefix == "" {
+		cfg.featurePrefix = "feature/"
+	}
+	if cfg.releasePrefix == "" {
+		cfg.releasePrefix = "release/"
+	}
+	if cfg.hotfixPrefix == "" {
+		cfg.hotfixPrefix = "hotfix/"
+	}
+	if cfg.tagPrefix == "" {
+		cfg.tagPrefix = "v"
+	}
+	if cfg.finishMode != "rebase" {
+		cfg.finishMode = "merge"
+	}
+	return cfg
+}
+
+// Retourne le chemin d'un fichier interne à Git (compatible worktrees)
+func (gm *GitManager) gitPath(name string) string {
+	output, err := gm.runGitCommand("rev-parse", "--git-path", name)
+	if err != nil {
+		return filepath.Join(gm.currentPath, ".git", name)
+	}
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(gm.currentPath, output)
+	}
+	return output
+}
+
+func (gm *GitManager) branchExists(branch string) bool {
+	_, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
+
+// Vérifications préalables: arbre propre et branche de base à jour avec son upstream
+func (gm *GitManager) flowPreflight(baseBranches ...string) bool {
+	fmt.Printf("%s🔎 Vérifications préalables...%s\n", ColorBlue, ColorReset)
+
+	if status, _ := gm.runGitCommand("status", "--porcelain", "--untracked-files=no"); status != "" {
+		fmt.Printf("%s❌ L'arbre de travail contient des modifications non commitées.%s\n", ColorRed, ColorReset)
+		fmt.Printf("%s💡 Commitez ou stashez vos changements avant de lancer le workflow.%s\n", ColorYellow, ColorReset)
+		return false
+	}
+	fmt.Printf("   %s✓ Arbre de travail propre%s\n", ColorGreen, ColorReset)
+
+	gm.runGitCommand("fetch", "--quiet", "origin")
+	for _, base := range baseBranches {
+		if !gm.branchExists(base) {
+			fmt.Printf("%s❌ La branche '%s' n'existe pas.%s\n", ColorRed, base, ColorReset)
+			return false
+		}
+
+		if gm.getUpstream(base) == "" {
+			fmt.Printf("   %s⚠ '%s' n'a pas d'upstream, synchronisation non vérifiée%s\n", ColorYellow, base, ColorReset)
+			continue
+		}
+
+		behind, _ := gm.runGitCommand("rev-list", "--count", base+".."+base+"@{u}")
+		if behind != "" && behind != "0" {
+			fmt.Printf("%s❌ '%s' a %s commit(s) de retard sur son upstream. Faites un pull d'abord.%s\n", ColorRed, base, behind, ColorReset)
+			return false
+		}
+		fmt.Printf("   %s✓ '%s' à jour avec son upstream%s\n", ColorGreen, base, ColorReset)
+	}
+	return true
+}
+
+func (gm *GitManager) beginFlow(branches ...string) *flowTransaction {
+	ft := &flowTransaction{
+		gm:             gm,
+		originalBranch: gm.getCurrentBranch(),
+		refs:           make(map[string]string),
+	}
+	for _, branch := range branches {
+		if sha, err := gm.runGitCommand("rev-parse", "refs/heads/"+branch); err == nil {
+			ft.refs[branch] = sha
+		}
+	}
+	return ft
+}
+
+// Exécute une étape du flow; en cas d'échec, annule tout le flow
+func (ft *flowTransaction) run(description string, args ...string) bool {
+	fmt.Printf("%s▶ %s%s\n", ColorCyan, description, ColorReset)
+	output, err := ft.gm.runGitCommand(args...)
+	if err != nil {
+		fmt.Printf("%s❌ Échec: %s%s\n", ColorRed, output, ColorReset)
+		ft.rollback()
+		return false
+	}
+	return true
+}
+
+// Restaure les branches, supprime les tags et branches créés et revient à la branche d'origine
+func (ft *flowTransaction) rollback() {
+	gm := ft.gm
+	fmt.Printf("%s↩️  Annulation du workflow...%s\n", ColorYellow, ColorReset)
+
+	if _, err := os.Stat(gm.gitPath("MERGE_HEAD")); err == nil {
+		gm.runGitCommand("merge", "--abort")
+	}
+	if _, err := os.Stat(gm.gitPath("rebase-merge")); err == nil {
+		gm.runGitCommand("rebase", "--abort")
+	}
+	if _, err := os.Stat(gm.gitPath("rebase-apply")); err == nil {
+		gm.runGitCommand("rebase", "--abort")
+	}
+
+	for branch, sha := range ft.refs {
+		gm.runGitCommand("update-ref", "refs/heads/"+branch, sha)
+	}
+	if ft.originalBranch != "" {
+		gm.runGitCommand("checkout", "-f", ft.originalBranch)
+		gm.runGitCommand("reset", "--hard", "HEAD")
+	}
+	for _, tag := range ft.createdTags {
+		gm.runGitCommand("tag", "-d", tag)
+	}
+	for _, branch := range ft.createdBranches {
+		if branch != ft.originalBranch {
+			gm.runGitCommand("branch", "-D", branch)
 		}
 	}
+
+	fmt.Printf("%s✅ État initial restauré (branche '%s').%s\n", ColorGreen, ft.originalBranch, ColorReset)
+}
+
+// Choisit une branche de travail ayant le préfixe donné (défaut: la branche actuelle si elle correspond)
+func (gm *GitManager) selectFlowBranch(prefix, label string) string {
+	branches, _ := gm.runGitCommand("for-each-ref", "--format=%(refname:short)", "refs/heads/"+prefix)
+	if branches == "" {
+		fmt.Printf("%s❌ Aucune branche %s ('%s*') trouvée.%s\n", ColorRed, label, prefix, ColorReset)
+		return ""
+	}
+
+	fmt.Printf("%sBranches %s:%s\n", ColorBlue, label, ColorReset)
+	fmt.Println(branches)
+
+	current := gm.getCurrentBranch()
+	defaultBranch := ""
+	if strings.HasPrefix(current, prefix) {
+		defaultBranch = current
+	}
+
+	fmt.Printf("\n%sBranche à terminer (défaut '%s'): %s", ColorYellow, defaultBranch, ColorReset)
+	branch := gm.getUserInput()
+	if branch == "" {
+		branch = defaultBranch
+	}
+	if branch != "" && !strings.HasPrefix(branch, prefix) && gm.branchExists(prefix+branch) {
+		branch = prefix + branch
+	}
+	if branch == "" || !gm.branchExists(branch) {
+		fmt.Printf("%s❌ Branche invalide!%s\n", ColorRed, ColorReset)
+		return ""
+	}
+	return branch
 }
 
-func (gm *GitManager) checkRepo() {
+func (gm *GitManager) handleWorkflow() {
+	if !gm.isGitRepo() {
+		fmt.Printf("%s❌ Ce répertoire n'est pas un dépôt Git!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
+
 	for {
 		gm.clearScreen()
-		fmt.Printf("%s🔍 VÉRIFICATION DU DÉPÔT%s\n", ColorBlue, ColorReset)
-		fmt.Println(strings.Repeat("═", 30))
-
-		fmt.Println("1. Vérifier l'intégrité (fsck)")
-		fmt.Println("2. Statistiques des objets (count-objects)")
-		fmt.Println("0. Retour")
+		cfg := gm.loadFlowConfig()
+
+		fmt.Printf("%s%s🔀 WORKFLOW FEATURE / RELEASE / HOTFIX%s\n", ColorBold, ColorGreen, ColorReset)
+		fmt.Println(strings.Repeat("═", 40))
+		fmt.Printf("%sBranche principale:%s %s   %sBranche d'intégration:%s %s\n",
+			ColorBlue, ColorReset, cfg.mainBranch, ColorBlue, ColorReset, cfg.developBranch)
+		if cfg.developBranch == cfg.mainBranch {
+			fmt.Printf("%s(mode trunk-based: une seule branche de base)%s\n", ColorCyan, ColorReset)
+		}
+		fmt.Printf("%sPréfixes:%s %s %s %s   %sFin de feature:%s %s\n\n",
+			ColorBlue, ColorReset, cfg.featurePrefix, cfg.releasePrefix, cfg.hotfixPrefix, ColorBlue, ColorReset, cfg.finishMode)
+
+		fmt.Println("1. Démarrer une feature")
+		fmt.Println("2. Terminer une feature")
+		fmt.Println("3. Démarrer une release")
+		fmt.Println("4. Terminer une release (avec tag)")
+		fmt.Println("5. Démarrer un hotfix")
+		fmt.Println("6. Terminer un hotfix")
+		fmt.Println("7. Configurer le workflow")
+		fmt.Println("0. Retour au menu principal")
 
 		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
 		choice := gm.getUserInput()
 
 		switch choice {
 		case "1":
-			fmt.Printf("%sVérification de l'intégrité...%s\n", ColorYellow, ColorReset)
-			output, err := gm.runGitCommand("fsck", "--full")
-			if err != nil || output != "" {
-				fmt.Printf("%s❌ Problèmes détectés:%s\n", ColorRed, ColorReset)
-				fmt.Println(output)
-			} else {
-				fmt.Printf("%s✅ Intégrité vérifiée! Aucun problème trouvé.%s\n", ColorGreen, ColorReset)
-			}
-			gm.pause()
+			gm.startFlowBranch(cfg.featurePrefix, cfg.developBranch, "feature")
 		case "2":
-			fmt.Printf("%s📊 Statistiques détaillées:%s\n", ColorBlue, ColorReset)
-			output, _ := gm.runGitCommand("count-objects", "-vH")
-			fmt.Println(output)
-			gm.pause()
+			gm.finishFeature(cfg)
+		case "3":
+			gm.startFlowBranch(cfg.releasePrefix, cfg.developBranch, "release")
+		case "4":
+			gm.finishReleaseOrHotfix(cfg, cfg.releasePrefix, "release")
+		case "5":
+			gm.startFlowBranch(cfg.hotfixPrefix, cfg.mainBranch, "hotfix")
+		case "6":
+			gm.finishReleaseOrHotfix(cfg, cfg.hotfixPrefix, "hotfix")
+		case "7":
+			gm.configureFlow(cfg)
 		case "0":
 			return
 		default:
@@ -3043,152 +9349,216 @@ func (gm *GitManager) checkRepo() {
 	}
 }
 
-func (gm *GitManager) manageHooks() {
-	hooksDir := filepath.Join(gm.currentPath, ".git", "hooks")
+func (gm *GitManager) startFlowBranch(prefix, base, label string) {
+	if !gm.flowPreflight(base) {
+		gm.pause()
+		return
+	}
 
-	for {
-		gm.clearScreen()
-		fmt.Printf("%s🪝 GESTION DES HOOKS%s\n", ColorPurple, ColorReset)
-		fmt.Println(strings.Repeat("═", 25))
+	if label == "feature" {
+		fmt.Printf("%sNom de la feature (sans '%s'): %s", ColorYellow, prefix, ColorReset)
+	} else {
+		fmt.Printf("%sVersion ou nom (%s, ex: 1.2.0): %s", ColorYellow, label, ColorReset)
+	}
+	name := strings.TrimPrefix(gm.getUserInput(), prefix)
+	if name == "" {
+		fmt.Printf("%s❌ Nom requis!%s\n", ColorRed, ColorReset)
+		gm.pause()
+		return
+	}
 
-		hooks, err := os.ReadDir(hooksDir)
-		if err != nil {
-			fmt.Printf("%s❌ Impossible de lire le répertoire hooks%s\n", ColorRed, ColorReset)
-			gm.pause()
-			return
-		}
+	branch := prefix + name
+	if err := gm.validateBranchName(branch); err != nil {
+		fmt.Printf("%s❌ %v%s\n", ColorRed, err, ColorReset)
+		gm.pause()
+		return
+	}
 
-		fmt.Printf("%s📋 Hooks disponibles:%s\n", ColorBlue, ColorReset)
-		for _, hook := range hooks {
-			if !strings.HasSuffix(hook.Name(), ".sample") {
-				fmt.Printf("%s✅ %s%s (actif)\n", ColorGreen, hook.Name(), ColorReset)
-			} else {
-				fmt.Printf("%s⚪ %s%s (exemple)\n", ColorYellow, hook.Name(), ColorReset)
-			}
-		}
+	if gm.branchExists(branch) {
+		fmt.Printf("%s❌ La branche '%s' existe déjà!%s\n", ColorRed, branch, ColorReset)
+		gm.pause()
+		return
+	}
 
-		fmt.Println("\n1. Activer un hook d'exemple")
-		fmt.Println("2. Désactiver un hook")
-		fmt.Println("3. Voir le contenu d'un hook")
-		fmt.Println("4. Créer un hook personnalisé")
-		fmt.Println("0. Retour")
+	ft := gm.beginFlow()
+	if ft.run(fmt.Sprintf("Création de '%s' depuis '%s'", branch, base), "checkout", "-b", branch, base) {
+		// Enregistrée seulement après création: un rollback ne doit jamais supprimer une branche existante
+		ft.createdBranches = append(ft.createdBranches, branch)
+		fmt.Printf("%s✅ %s '%s' démarré(e) depuis '%s'!%s\n", ColorGreen, label, branch, base, ColorReset)
+	}
+	gm.pause()
+}
 
-		fmt.Printf("\n%sChoisissez une option: %s", ColorYellow, ColorReset)
-		choice := gm.getUserInput()
+func (gm *GitManager) finishFeature(cfg flowConfig) {
+	branch := gm.selectFlowBranch(cfg.featurePrefix, "feature")
+	if branch == "" {
+		gm.pause()
+		return
+	}
 
-		switch choice {
-		case "1":
-			fmt.Printf("%sNom du hook à activer (sans .sample): %s", ColorYellow, ColorReset)
-			hookName := gm.getUserInput()
-			if hookName != "" {
-				samplePath := filepath.Join(hooksDir, hookName+".sample")
-				hookPath := filepath.Join(hooksDir, hookName)
+	base := cfg.developBranch
+	if !gm.flowPreflight(base) {
+		gm.pause()
+		return
+	}
 
-				if _, err := os.Stat(samplePath); err == nil {
-					content, _ := os.ReadFile(samplePath)
-					err := os.WriteFile(hookPath, content, 0755)
-					if err != nil {
-						fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, err.Error(), ColorReset)
-					} else {
-						fmt.Printf("%s✅ Hook '%s' activé!%s\n", ColorGreen, hookName, ColorReset)
-					}
-				} else {
-					fmt.Printf("%s❌ Hook d'exemple '%s.sample' introuvable!%s\n", ColorRed, hookName, ColorReset)
-				}
-			}
-			gm.pause()
-		case "2":
-			fmt.Printf("%sNom du hook à désactiver: %s", ColorYellow, ColorReset)
-			hookName := gm.getUserInput()
-			if hookName != "" {
-				hookPath := filepath.Join(hooksDir, hookName)
-				err := os.Remove(hookPath)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, err.Error(), ColorReset)
-				} else {
-					fmt.Printf("%s✅ Hook '%s' désactivé!%s\n", ColorGreen, hookName, ColorReset)
-				}
-			}
+	fmt.Printf("%sMode d'intégration: 1. merge --no-ff  2. rebase + fast-forward (défaut %s): %s", ColorYellow, cfg.finishMode, ColorReset)
+	mode := cfg.finishMode
+	switch gm.getUserInput() {
+	case "1":
+		mode = "merge"
+	case "2":
+		mode = "rebase"
+	}
+
+	ft := gm.beginFlow(base, branch)
+	if mode == "rebase" {
+		if !ft.run(fmt.Sprintf("Rebase de '%s' sur '%s'", branch, base), "rebase", base, branch) ||
+			!ft.run(fmt.Sprintf("Checkout de '%s'", base), "checkout", base) ||
+			!ft.run(fmt.Sprintf("Fast-forward de '%s'", base), "merge", "--ff-only", branch) {
 			gm.pause()
-		case "3":
-			fmt.Printf("%sNom du hook à voir: %s", ColorYellow, ColorReset)
-			hookName := gm.getUserInput()
-			if hookName != "" {
-				hookPath := filepath.Join(hooksDir, hookName)
-				content, err := os.ReadFile(hookPath)
-				if err != nil {
-					fmt.Printf("%s❌ Hook introuvable: %s%s\n", ColorRed, err.Error(), ColorReset)
-				} else {
-					fmt.Printf("%s📄 Contenu du hook '%s':%s\n", ColorBlue, hookName, ColorReset)
-					fmt.Println(string(content))
-				}
-			}
+			return
+		}
+	} else {
+		if !ft.run(fmt.Sprintf("Checkout de '%s'", base), "checkout", base) ||
+			!ft.run(fmt.Sprintf("Merge --no-ff de '%s'", branch), "merge", "--no-ff", "-m", fmt.Sprintf("Merge branch '%s' into %s", branch, base), branch) {
 			gm.pause()
-		case "4":
-			fmt.Printf("%sNom du nouveau hook: %s", ColorYellow, ColorReset)
-			hookName := gm.getUserInput()
-			if hookName != "" {
-				hookContent := `#!/bin/sh
-# Hook personnalisé: ` + hookName + `
-# Créé le ` + time.Now().Format("2006-01-02 15:04:05") + `
+			return
+		}
+	}
+	if !ft.run(fmt.Sprintf("Suppression de '%s'", branch), "branch", "-d", branch) {
+		gm.pause()
+		return
+	}
+	fmt.Printf("%s✅ Feature '%s' intégrée dans '%s'!%s\n", ColorGreen, branch, base, ColorReset)
 
-echo "Exécution du hook ` + hookName + `"
+	gm.pushFlowResult([]string{base}, nil, []string{branch})
+	gm.pause()
+}
 
-# Ajoutez votre code ici
-exit 0
-`
-				hookPath := filepath.Join(hooksDir, hookName)
-				err := os.WriteFile(hookPath, []byte(hookContent), 0755)
-				if err != nil {
-					fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, err.Error(), ColorReset)
-				} else {
-					fmt.Printf("%s✅ Hook '%s' créé!%s\n", ColorGreen, hookName, ColorReset)
-				}
-			}
+func (gm *GitManager) finishReleaseOrHotfix(cfg flowConfig, prefix, label string) {
+	branch := gm.selectFlowBranch(prefix, label)
+	if branch == "" {
+		gm.pause()
+		return
+	}
+
+	targets := []string{cfg.mainBranch}
+	if cfg.developBranch != cfg.mainBranch {
+		targets = append(targets, cfg.developBranch)
+	}
+	if !gm.flowPreflight(targets...) {
+		gm.pause()
+		return
+	}
+
+	version := strings.TrimPrefix(branch, prefix)
+	tag := cfg.tagPrefix + strings.TrimPrefix(version, cfg.tagPrefix)
+	fmt.Printf("%sTag à créer (défaut '%s', '-' pour aucun): %s", ColorYellow, tag, ColorReset)
+	if input := gm.getUserInput(); input == "-" {
+		tag = ""
+	} else if input != "" {
+		tag = input
+	}
+	if tag != "" {
+		if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/tags/"+tag); err == nil {
+			fmt.Printf("%s❌ Le tag '%s' existe déjà!%s\n", ColorRed, tag, ColorReset)
 			gm.pause()
-		case "0":
 			return
-		default:
-			fmt.Printf("%s❌ Option invalide!%s\n", ColorRed, ColorReset)
+		}
+	}
+
+	ft := gm.beginFlow(append(targets, branch)...)
+	for i, target := range targets {
+		if !ft.run(fmt.Sprintf("Checkout de '%s'", target), "checkout", target) ||
+			!ft.run(fmt.Sprintf("Merge --no-ff de '%s' dans '%s'", branch, target), "merge", "--no-ff", "-m", fmt.Sprintf("Merge branch '%s' into %s", branch, target), branch) {
 			gm.pause()
+			return
+		}
+
+		// Le tag est posé sur le merge dans la branche principale
+		if i == 0 && tag != "" {
+			if !ft.run(fmt.Sprintf("Création du tag '%s'", tag), "tag", "-a", tag, "-m", fmt.Sprintf("%s%s %s", strings.ToUpper(label[:1]), label[1:], version)) {
+				gm.pause()
+				return
+			}
+			ft.createdTags = append(ft.createdTags, tag)
 		}
 	}
-}
+	if !ft.run(fmt.Sprintf("Suppression de '%s'", branch), "branch", "-d", branch) {
+		gm.pause()
+		return
+	}
+	fmt.Printf("%s✅ %s '%s' terminé(e)!%s\n", ColorGreen, label, branch, ColorReset)
 
-func (gm *GitManager) manageAliases() {
-	// This is a complex feature, so we'll provide a simpler interface
-	fmt.Printf("%sLes alias Git sont gérés dans votre fichier de configuration Git (~/.gitconfig).%s\n", ColorYellow, ColorReset)
-	fmt.Println("Exemples d'alias utiles:")
-	fmt.Println("  git config --global alias.co checkout")
-	fmt.Println("  git config --global alias.br branch")
-	fmt.Println("  git config --global alias.ci commit")
-	fmt.Println("  git config --global alias.st status")
-	fmt.Println("  git config --global alias.last 'log -1 HEAD'")
+	var tags []string
+	if tag != "" {
+		tags = append(tags, tag)
+	}
+	gm.pushFlowResult(targets, tags, []string{branch})
 	gm.pause()
 }
 
-func (gm *GitManager) archiveRepo() {
-	fmt.Printf("%sFormat d'archive (zip, tar.gz): %s", ColorYellow, ColorReset)
-	format := gm.getUserInput()
-	if format != "zip" && format != "tar.gz" {
-		fmt.Printf("%s❌ Format invalide. Utilisez 'zip' ou 'tar.gz'.%s\n", ColorRed, ColorReset)
-		gm.pause()
+// Propose de pusher les branches et tags d'un flow terminé et de supprimer les branches remote
+func (gm *GitManager) pushFlowResult(branches, tags, deleted []string) {
+	fmt.Printf("\n%sPusher le résultat vers origin? (y/N): %s", ColorYellow, ColorReset)
+	if strings.ToLower(gm.getUserInput()) != "y" {
 		return
 	}
 
-	defaultFileName := filepath.Base(gm.currentPath) + "." + format
-	fmt.Printf("%sNom du fichier de sortie (défaut: %s): %s", ColorYellow, defaultFileName, ColorReset)
-	outputFile := gm.getUserInput()
-	if outputFile == "" {
-		outputFile = defaultFileName
+	for _, branch := range branches {
+		output, err := gm.runGitCommand("push", "origin", branch)
+		if err != nil {
+			fmt.Printf("%s❌ Push de '%s' échoué: %s%s\n", ColorRed, branch, output, ColorReset)
+			fmt.Printf("%s💡 Les changements locaux sont conservés, relancez le push manuellement.%s\n", ColorYellow, ColorReset)
+			return
+		}
+		fmt.Printf("%s✅ '%s' pushée%s\n", ColorGreen, branch, ColorReset)
+	}
+	for _, tag := range tags {
+		if output, err := gm.runGitCommand("push", "origin", "refs/tags/"+tag); err != nil {
+			fmt.Printf("%s❌ Push du tag '%s' échoué: %s%s\n", ColorRed, tag, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Tag '%s' pushé%s\n", ColorGreen, tag, ColorReset)
+		}
+	}
+	for _, branch := range deleted {
+		if _, err := gm.runGitCommand("rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch); err != nil {
+			continue
+		}
+		if output, err := gm.runGitCommand("push", "origin", "--delete", branch); err != nil {
+			fmt.Printf("%s❌ Suppression remote de '%s' échouée: %s%s\n", ColorRed, branch, output, ColorReset)
+		} else {
+			fmt.Printf("%s✅ Branche remote '%s' supprimée%s\n", ColorGreen, branch, ColorReset)
+		}
 	}
+}
 
-	output, err := gm.runGitCommand("archive", "--format="+format, "-o", outputFile, "HEAD")
-	if err != nil {
-		fmt.Printf("%s❌ Erreur lors de la création de l'archive: %s%s\n", ColorRed, output, ColorReset)
-	} else {
-		fmt.Printf("%s✅ Archive '%s' créée avec succès!%s\n", ColorGreen, outputFile, ColorReset)
+func (gm *GitManager) configureFlow(cfg flowConfig) {
+	settings := []struct {
+		key, label, current string
+	}{
+		{"gitman.flow.main", "Branche principale", cfg.mainBranch},
+		{"gitman.flow.develop", "Branche d'intégration (= principale pour trunk-based)", cfg.developBranch},
+		{"gitman.flow.featurePrefix", "Préfixe des features", cfg.featurePrefix},
+		{"gitman.flow.releasePrefix", "Préfixe des releases", cfg.releasePrefix},
+		{"gitman.flow.hotfixPrefix", "Préfixe des hotfixes", cfg.hotfixPrefix},
+		{"gitman.flow.tagPrefix", "Préfixe des tags", cfg.tagPrefix},
+		{"gitman.flow.finishMode", "Fin de feature (merge/rebase)", cfg.finishMode},
+	}
+
+	fmt.Printf("%sLaissez vide pour conserver la valeur actuelle.%s\n", ColorCyan, ColorReset)
+	for _, setting := range settings {
+		fmt.Printf("%s%s (actuel '%s'): %s", ColorYellow, setting.label, setting.current, ColorReset)
+		value := gm.getUserInput()
+		if value == "" {
+			continue
+		}
+		if output, err := gm.runGitCommand("config", setting.key, value); err != nil {
+			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
+		}
 	}
+	fmt.Printf("%s✅ Workflow configuré!%s\n", ColorGreen, ColorReset)
 	gm.pause()
 }
 
@@ -3258,8 +9628,12 @@ func (gm *GitManager) handleQuickCommit() {
 	if staged != "" {
 		fmt.Printf("%s✅ Fichiers en stage:%s\n", ColorGreen, ColorReset)
 		fmt.Println(staged)
-		fmt.Printf("\n%sMessage de commit: %s", ColorYellow, ColorReset)
-		message := gm.getUserInput()
+		if !gm.checkStagedChanges() {
+			gm.pause()
+			return
+		}
+		fmt.Println()
+		message := gm.promptCommitMessage()
 		if message != "" {
 			output, err := gm.runGitCommand("commit", "-m", message)
 			if err != nil {
@@ -3405,14 +9779,19 @@ func (gm *GitManager) handleQuickRemote() {
 	ahead, _ := gm.runGitCommand("rev-list", "--count", "@{u}..HEAD")
 	behind, _ := gm.runGitCommand("rev-list", "--count", "HEAD..@{u}")
 
-	if ahead != "0" && ahead != "" {
-		fmt.Printf("%s📤 %s commit(s) à pusher%s\n", ColorYellow, ahead, ColorReset)
-	}
-	if behind != "0" && behind != "" {
-		fmt.Printf("%s📥 %s commit(s) à puller%s\n", ColorYellow, behind, ColorReset)
-	}
-	if (ahead == "0" || ahead == "") && (behind == "0" || behind == "") {
-		fmt.Printf("%s✅ Votre branche est à jour avec le remote.%s\n", ColorGreen, ColorReset)
+	upstream := gm.getUpstream(gm.getCurrentBranch())
+	if upstream == "" {
+		fmt.Printf("%s⚠️  Aucun upstream configuré: le push rapide proposera --set-upstream%s\n", ColorYellow, ColorReset)
+	} else {
+		if ahead != "0" && ahead != "" {
+			fmt.Printf("%s📤 %s commit(s) à pusher%s\n", ColorYellow, ahead, ColorReset)
+		}
+		if behind != "0" && behind != "" {
+			fmt.Printf("%s📥 %s commit(s) à puller%s\n", ColorYellow, behind, ColorReset)
+		}
+		if (ahead == "0" || ahead == "") && (behind == "0" || behind == "") {
+			fmt.Printf("%s✅ Votre branche est à jour avec %s.%s\n", ColorGreen, upstream, ColorReset)
+		}
 	}
 
 	fmt.Printf("\n%s1.%s Push rapide (origin + branche actuelle)\n", ColorCyan, ColorReset)
@@ -3428,8 +9807,15 @@ func (gm *GitManager) handleQuickRemote() {
 
 	switch choice {
 	case "1":
+		args := []string{"push", "origin", currentBranch}
+		if upstream == "" {
+			fmt.Printf("%s🔌 Définir origin/%s comme upstream (--set-upstream)? (y/N): %s", ColorYellow, currentBranch, ColorReset)
+			if strings.ToLower(gm.getUserInput()) == "y" {
+				args = []string{"push", "--set-upstream", "origin", currentBranch}
+			}
+		}
 		fmt.Printf("%sPush vers origin/%s...%s\n", ColorYellow, currentBranch, ColorReset)
-		output, err := gm.runGitCommand("push", "origin", currentBranch)
+		output, err := gm.runGitCommand(args...)
 		if err != nil {
 			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
 		} else {
@@ -3438,12 +9824,7 @@ func (gm *GitManager) handleQuickRemote() {
 		gm.pause()
 	case "2":
 		fmt.Printf("%sPull depuis origin/%s...%s\n", ColorYellow, currentBranch, ColorReset)
-		output, err := gm.runGitCommand("pull", "origin", currentBranch)
-		if err != nil {
-			fmt.Printf("%s❌ Erreur: %s%s\n", ColorRed, output, ColorReset)
-		} else {
-			fmt.Printf("%s✅ Pull terminé!%s\n", ColorGreen, ColorReset)
-		}
+		gm.pullWithStrategy("origin", currentBranch)
 		gm.pause()
 	case "3":
 		gm.fetchFromRemote() // This function already pauses
@@ -3455,6 +9836,15 @@ func (gm *GitManager) handleQuickRemote() {
 
 // Modification de la fonction main pour gérer les raccourcis
 func main() {
+	// Mode éditeur de séquence: appelé par git pendant un rebase interactif piloté par gitman
+	if len(os.Args) == 4 && os.Args[1] == "--sequence-editor" {
+		if err := runSequenceEditor(os.Args[2], os.Args[3]); err != nil {
+			fmt.Fprintf(os.Stderr, "gitman: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	gm := NewGitManager()
 	for {
 		gm.clearScreen()
@@ -3497,11 +9887,15 @@ func main() {
 			gm.changeDirectory()
 		case "11":
 			gm.initRepo()
+		case "12":
+			gm.handleWorkflow()
+		case "13":
+			gm.handleWorktrees()
 		case "0":
 			fmt.Println("👋 Au revoir!")
 			return
 		default:
-			fmt.Printf("%s❌ Option invalide! Utilisez les chiffres (0-11) ou les lettres (S,C,F,B,R)%s\n", ColorRed, ColorReset)
+			fmt.Printf("%s❌ Option invalide! Utilisez les chiffres (0-13) ou les lettres (S,C,F,B,R)%s\n", ColorRed, ColorReset)
 			gm.pause()
 		}
 	}